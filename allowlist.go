@@ -0,0 +1,44 @@
+package logger
+
+// SetFieldAllowlist restricts entries to fields whose key is in keys: all other fields,
+// whether attached with WithFields, passed to the call or added by the logger itself
+// (e.g. "error", "data", "trace_id"), are dropped, and a "dropped_fields" field reports how
+// many were removed from the entry. Keys are matched exactly against field keys only: an
+// allowed Any field is kept whole, including its nested keys (see SetRedactKeys for
+// hiding those). Calling it with no keys disables the allowlist (the default).
+func (l *Logger) SetFieldAllowlist(keys []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(keys) == 0 {
+		l.fieldAllowlist = nil
+		return
+	}
+	l.fieldAllowlist = make(map[string]bool, len(keys))
+	for _, k := range keys {
+		l.fieldAllowlist[k] = true
+	}
+}
+
+// allowFields returns the allowlisted subset of fields, copying only if needed.
+// Must be called with l.mu held.
+func (l *Logger) allowFields(fields []Field) []Field {
+	if l.fieldAllowlist == nil {
+		return fields
+	}
+	dropped := 0
+	for _, f := range fields {
+		if !l.fieldAllowlist[f.Key] {
+			dropped++
+		}
+	}
+	if dropped == 0 {
+		return fields
+	}
+	out := make([]Field, 0, len(fields)-dropped+1)
+	for _, f := range fields {
+		if l.fieldAllowlist[f.Key] {
+			out = append(out, f)
+		}
+	}
+	return append(out, Int("dropped_fields", dropped))
+}
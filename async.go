@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// ErrCloseTimeout is returned by CloseWithTimeout when queued output could not be written in time.
+var ErrCloseTimeout = errors.New("logger: close timed out with queued output")
+
+// asyncWriter forwards writes to w from a background goroutine.
+// When the queue is full, writes are dropped instead of blocking.
+type asyncWriter struct {
+	w         io.Writer
+	ch        chan []byte
+	done      chan struct{}
+	dropped   atomic.Uint64
+	abandoned atomic.Bool
+	pending   atomic.Int64 // queued or in-flight entries
+}
+
+// newAsyncWriter starts a writer goroutine with a queue of size entries.
+func newAsyncWriter(w io.Writer, size int) *asyncWriter {
+	a := &asyncWriter{w: w, ch: make(chan []byte, size), done: make(chan struct{})}
+	go a.run()
+	return a
+}
+
+// run writes queued entries until the queue is closed.
+func (a *asyncWriter) run() {
+	defer close(a.done)
+	for p := range a.ch {
+		if !a.abandoned.Load() {
+			_, _ = a.w.Write(p)
+		}
+		a.pending.Add(-1)
+	}
+}
+
+// Write implements io.Writer. It never blocks; p is dropped if the queue is full.
+func (a *asyncWriter) Write(p []byte) (int, error) {
+	a.pending.Add(1)
+	select {
+	case a.ch <- append([]byte(nil), p...):
+	default:
+		a.pending.Add(-1)
+		a.dropped.Add(1)
+	}
+	return len(p), nil
+}
+
+// writeBlocking queues p, waiting for room instead of dropping it.
+func (a *asyncWriter) writeBlocking(p []byte) {
+	a.pending.Add(1)
+	a.ch <- append([]byte(nil), p...)
+}
+
+// waitIdle waits up to d for all queued entries to be written.
+func (a *asyncWriter) waitIdle(d time.Duration) {
+	deadline := time.Now().Add(d)
+	for a.pending.Load() > 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Microsecond)
+	}
+}
+
+// close stops accepting writes and waits for queued entries to be written.
+func (a *asyncWriter) close() {
+	close(a.ch)
+	<-a.done
+}
+
+// closeTimeout stops accepting writes and waits up to d (indefinitely if d <= 0) for queued
+// entries to be written. It returns the number of dropped entries, including those still
+// queued at the deadline, and whether the queue was fully drained.
+func (a *asyncWriter) closeTimeout(d time.Duration) (uint64, bool) {
+	close(a.ch)
+	if d <= 0 {
+		<-a.done
+		return a.dropped.Load(), true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-a.done:
+		return a.dropped.Load(), true
+	case <-timer.C:
+		// Let the goroutine discard the rest once the stuck write returns.
+		a.abandoned.Store(true)
+		return a.dropped.Load() + uint64(len(a.ch)), false
+	}
+}
+
+// SetConsoleNonBlocking routes console output through a background goroutine with a
+// queue of bufferSize entries, so a slow terminal or full pipe never stalls logging.
+// Entries that do not fit in the queue are dropped and counted (see ConsoleDropped).
+// File output is unaffected and stays synchronous. A bufferSize <= 0 restores blocking writes.
+func (l *Logger) SetConsoleNonBlocking(bufferSize int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	console := l.console
+	if l.consoleAsync != nil {
+		console = l.consoleAsync.w
+		l.consoleAsync.close()
+		l.consoleAsync = nil
+	}
+	if bufferSize > 0 && console != nil {
+		l.consoleAsync = newAsyncWriter(console, bufferSize)
+		console = l.consoleAsync
+	}
+	l.console = console
+}
+
+// ConsoleDropped returns the number of console entries dropped in non-blocking mode.
+func (l *Logger) ConsoleDropped() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.consoleAsync == nil {
+		return 0
+	}
+	return l.consoleAsync.dropped.Load()
+}
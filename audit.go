@@ -0,0 +1,21 @@
+package logger
+
+import "time"
+
+// Audit logs a message that must not be lost, e.g. a security event. It is written at
+// INFO level with an audit=true field to every configured output regardless of the
+// console, file and sink levels, bypasses sampling, waits for room in the non-blocking
+// console queue instead of dropping the entry, and syncs the log file before returning.
+// It does not open the default log file when file logging is DISABLED.
+func (l *Logger) Audit(format string, args ...interface{}) {
+	msg, fields := l.formatArgs(format, args)
+	fields = append(fields, Bool("audit", true))
+
+	t := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e := l.newEntry(t, INFO, msg, fields)
+	e.audit = true
+	l.writeEntry(&e)
+}
@@ -0,0 +1,30 @@
+package logger
+
+import "strings"
+
+// SetAutoData enables automatic structured logging of a lone argument: when a log call
+// has exactly one non-string argument and the format contains no verbs, e.g.
+//
+//	log.Debug("request", req)
+//
+// the argument is attached as a "data" field (a nested object in JSON, compact JSON in text)
+// instead of producing "request%!(EXTRA ...)".
+func (l *Logger) SetAutoData(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.autoData = enabled
+}
+
+// isAutoData reports whether a call with format and the single argument arg should
+// attach arg as a data field.
+func (l *Logger) isAutoData(format string, arg interface{}) bool {
+	if strings.IndexByte(format, '%') >= 0 {
+		return false
+	}
+	if _, ok := arg.(string); ok {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.autoData
+}
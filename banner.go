@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// bannerColor is the color of console banners.
+const bannerColor = "\033[1;36m" // bold cyan
+
+// Banner logs text at INFO level as a bordered, colorized block on a text console, e.g.
+// for "MyApp v1.2 starting" at startup. The border adapts to the longest line of text.
+// The log file and JSON outputs get a regular entry with a banner=true field.
+func (l *Logger) Banner(text string) {
+	t := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e := l.newEntry(t, INFO, text, []Field{Bool("banner", true)})
+	e.banner = true
+	l.writeEntry(&e)
+}
+
+// appendBanner appends e.message framed by a border, without the trailing newline.
+func appendBanner(buf []byte, e *entry, color bool) []byte {
+	lines := strings.Split(stripHighlights(e.message), "\n")
+	width := 0
+	for _, line := range lines {
+		if n := utf8.RuneCountInString(line); n > width {
+			width = n
+		}
+	}
+	border := "+" + strings.Repeat("-", width+4) + "+"
+
+	if color {
+		buf = append(buf, bannerColor...)
+	}
+	buf = append(buf, border...)
+	for _, line := range lines {
+		buf = append(buf, "\n|  "...)
+		buf = appendSanitized(buf, line, false)
+		buf = append(buf, strings.Repeat(" ", width-utf8.RuneCountInString(line))...)
+		buf = append(buf, "  |"...)
+	}
+	buf = append(buf, '\n')
+	buf = append(buf, border...)
+	if color {
+		buf = append(buf, reset...)
+	}
+	return buf
+}
@@ -0,0 +1,17 @@
+package logger
+
+import "time"
+
+// Batch writes lines as consecutive entries at the given level under a single lock
+// acquisition, so output from other goroutines cannot interleave with them.
+// All lines share the same timestamp and are never sampled.
+func (l *Logger) Batch(level LogLevel, lines []string) {
+	t := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, line := range lines {
+		l.emit(t, level, line, nil)
+	}
+}
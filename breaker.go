@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrSinkDisabled is reported when a circuit breaker stops writing to a failing sink.
+var ErrSinkDisabled = errors.New("logger: sink disabled")
+
+// circuitBreaker stops writing to a sink after repeated failures, see NewCircuitBreaker.
+type circuitBreaker struct {
+	sink        Sink
+	maxFailures int
+	cooldown    time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time // zero while the breaker is closed
+}
+
+// NewCircuitBreaker wraps s so that after maxFailures consecutive failed writes, entries
+// are discarded without calling s for the cooldown period. The next write after the
+// cooldown is a probe: if it succeeds the sink is re-enabled, otherwise it stays disabled
+// for another cooldown. When attached with AddSink, the logger writes a single FAIL
+// "sink disabled" notice to its other outputs each time the breaker opens.
+func NewCircuitBreaker(s Sink, maxFailures int, cooldown time.Duration) Sink {
+	if maxFailures < 1 {
+		maxFailures = 1
+	}
+	return &circuitBreaker{sink: s, maxFailures: maxFailures, cooldown: cooldown}
+}
+
+// Write forwards the entry unless the breaker is open. It returns an error wrapping
+// ErrSinkDisabled when this write opened the breaker, and nil for discarded entries.
+func (b *circuitBreaker) Write(t time.Time, level LogLevel, entry []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	probe := !b.openUntil.IsZero()
+	if probe && now.Before(b.openUntil) {
+		return nil
+	}
+
+	err := b.sink.Write(t, level, entry)
+	if err == nil {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return nil
+	}
+	if probe {
+		b.openUntil = now.Add(b.cooldown)
+		return nil
+	}
+	b.failures++
+	if b.failures < b.maxFailures {
+		return err
+	}
+	b.openUntil = now.Add(b.cooldown)
+	return fmt.Errorf("%w after %d consecutive failures: %w", ErrSinkDisabled, b.failures, err)
+}
+
+// Close closes the wrapped sink.
+func (b *circuitBreaker) Close() error {
+	return b.sink.Close()
+}
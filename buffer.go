@@ -0,0 +1,30 @@
+package logger
+
+import "sync"
+
+// maxPooledBuffer is the largest buffer capacity returned to the pool;
+// bigger buffers (from huge messages) are left to the garbage collector.
+const maxPooledBuffer = 64 << 10
+
+// bufPool recycles the byte buffers entries are rendered into.
+var bufPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *[]byte {
+	b := bufPool.Get().(*[]byte)
+	*b = (*b)[:0]
+	return b
+}
+
+// putBuffer returns b to the pool. b must not be used afterwards.
+func putBuffer(b *[]byte) {
+	if cap(*b) > maxPooledBuffer {
+		return
+	}
+	bufPool.Put(b)
+}
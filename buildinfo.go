@@ -0,0 +1,46 @@
+package logger
+
+import "runtime/debug"
+
+// SetBuildInfo adds the main module's version (build.version), VCS revision
+// (build.revision) and commit time (build.time) embedded by the Go toolchain to every
+// entry written by l and the loggers sharing its configuration. Values missing from the
+// binary are omitted: "go run" and builds outside a module have no version, and the VCS
+// settings are only recorded when building from a repository checkout (see -buildvcs).
+// It reports whether any value was found.
+func (l *Logger) SetBuildInfo() bool {
+	fields := buildInfoFields()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buildFields = fields
+	return len(fields) > 0
+}
+
+// buildInfoFields returns the build information of the running binary as fields.
+func buildInfoFields() []Field {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	var fields []Field
+	if v := info.Main.Version; v != "" && v != "(devel)" {
+		fields = append(fields, Str("build.version", v))
+	}
+	var revision, commitTime string
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.time":
+			commitTime = s.Value
+		}
+	}
+	if revision != "" {
+		fields = append(fields, Str("build.revision", revision))
+	}
+	if commitTime != "" {
+		fields = append(fields, Str("build.time", commitTime))
+	}
+	return fields
+}
@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// CallerMode controls whether and how the calling location is included in log entries.
+type CallerMode int
+
+// Available caller modes.
+const (
+	CallerOff   CallerMode = iota // no caller information
+	CallerShort                   // file:line
+	CallerFull                    // pkg.Func:file:line
+)
+
+// pkgPrefix is the fully qualified function name prefix of this package (e.g. "github.com/dozerokz/logger.").
+// Frames starting with it are skipped when looking up the caller.
+var pkgPrefix = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	return name[:slash+1+dot+1]
+}()
+
+// SetCallerMode sets how the calling location is included in log entries.
+func (l *Logger) SetCallerMode(mode CallerMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.callerMode = mode
+}
+
+// SetIncludePackage sets whether the package name of the calling function (e.g. "db"
+// for github.com/acme/app/db) is included in log entries. It is cheaper than full
+// caller information and identifies the component without a named logger.
+func (l *Logger) SetIncludePackage(include bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.includePackage = include
+}
+
+// callerInfo returns the location of the first stack frame outside this package
+// (and the runtime, so recovered panics point at the panicking function) formatted per mode.
+func callerInfo(mode CallerMode) string {
+	if mode == CallerOff {
+		return ""
+	}
+	frame, ok := callerFrame()
+	if !ok {
+		return "???"
+	}
+	return formatCaller(mode, frame)
+}
+
+// callerPackage returns the last element of the package path of the calling function.
+func callerPackage() string {
+	frame, ok := callerFrame()
+	if !ok {
+		return ""
+	}
+	fn := frame.Function
+	if slash := strings.LastIndex(fn, "/"); slash >= 0 {
+		fn = fn[slash+1:]
+	}
+	if dot := strings.Index(fn, "."); dot >= 0 {
+		fn = fn[:dot]
+	}
+	return fn
+}
+
+// callerFrame returns the first stack frame outside this package and the runtime.
+func callerFrame() (runtime.Frame, bool) {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) && !strings.HasPrefix(frame.Function, "runtime.") {
+			return frame, true
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+// formatCaller renders a stack frame according to mode.
+func formatCaller(mode CallerMode, frame runtime.Frame) string {
+	loc := filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+	if mode != CallerFull {
+		return loc
+	}
+	fn := frame.Function
+	if slash := strings.LastIndex(fn, "/"); slash >= 0 {
+		fn = fn[slash+1:]
+	}
+	return fn + ":" + loc
+}
+
+// stackTrace returns the current goroutine's stack starting at the first frame
+// outside this package, one "function\n\tfile:line" pair per frame.
+func stackTrace() string {
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var sb strings.Builder
+	skipping := true
+	for {
+		frame, more := frames.Next()
+		if skipping && strings.HasPrefix(frame.Function, pkgPrefix) {
+			if !more {
+				break
+			}
+			continue
+		}
+		skipping = false
+		sb.WriteString(frame.Function)
+		sb.WriteString("\n\t")
+		sb.WriteString(frame.File)
+		sb.WriteByte(':')
+		sb.WriteString(strconv.Itoa(frame.Line))
+		sb.WriteByte('\n')
+		if !more {
+			break
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,32 @@
+package logger
+
+import "bytes"
+
+// WithCapturedOutput redirects console and file output to in-memory buffers while fn runs
+// and returns what was written to each; console entries routed to stderr (see
+// SetStderrLevel) are captured with the console output. The previous outputs are restored afterwards,
+// even if fn panics. Entries logged concurrently by other goroutines are captured too.
+func (l *Logger) WithCapturedOutput(fn func()) (consoleOutput, fileOutput string) {
+	var console, file bytes.Buffer
+
+	l.mu.Lock()
+	_ = l.flushFile()
+	prevConsole, prevStderr, prevFile := l.console, l.stderr, l.file
+	l.console, l.stderr, l.file = &console, &console, &file
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		_ = l.flushFile()
+		l.console, l.stderr, l.file = prevConsole, prevStderr, prevFile
+		l.mu.Unlock()
+	}()
+
+	fn()
+
+	l.mu.Lock()
+	_ = l.flushFile()
+	consoleOutput, fileOutput = console.String(), file.String()
+	l.mu.Unlock()
+	return consoleOutput, fileOutput
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCapturedOutputStderrLevel(t *testing.T) {
+	stderr, err := os.Create(filepath.Join(t.TempDir(), "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stderr.Close()
+	prev := os.Stderr
+	os.Stderr = stderr
+	defer func() { os.Stderr = prev }()
+
+	l := NewLogger(DEBUG, DISABLED)
+	defer l.Close()
+	l.SetColorEnabled(false)
+	l.SetStderrLevel(ERROR)
+	console, _ := l.WithCapturedOutput(func() {
+		l.Info("to stdout")
+		l.Error("to stderr")
+	})
+
+	got := textMessages(console)
+	if len(got) != 2 || got[0] != "to stdout" || got[1] != "to stderr" {
+		t.Errorf("captured %q, want both entries", got)
+	}
+	if leaked, _ := os.ReadFile(stderr.Name()); len(leaked) > 0 {
+		t.Errorf("entry leaked to stderr: %q", leaked)
+	}
+}
@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// checksumExt is the extension of checksum sidecar files.
+const checksumExt = ".sha256"
+
+// SetChecksumOnRotate enables writing a SHA-256 sidecar file ("<rotated>.sha256", in
+// sha256sum format) for every rotated log file, computed in the background. It applies
+// to Rotate and to a *RotatingFile set with SetFileWriter.
+func (l *Logger) SetChecksumOnRotate(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.checksumOnRotate = enabled
+	if r, ok := l.file.(*RotatingFile); ok {
+		r.mu.Lock()
+		r.opts.Checksum = enabled
+		r.mu.Unlock()
+	}
+}
+
+// writeChecksum writes the SHA-256 of path to path+".sha256".
+func writeChecksum(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	line := hex.EncodeToString(h.Sum(nil)) + "  " + filepath.Base(path) + "\n"
+	return os.WriteFile(path+checksumExt, []byte(line), 0644)
+}
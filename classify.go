@@ -0,0 +1,74 @@
+package logger
+
+import "sort"
+
+// RegisterErrorClassifier adds fn to the classifiers run for every error logged with Err
+// or WrapError. The map fn returns (nil if it does not recognize the error) is merged into
+// the entry as fields, sorted by key, e.g.
+//
+//	log.RegisterErrorClassifier(func(err error) map[string]interface{} {
+//		if errors.Is(err, context.DeadlineExceeded) {
+//			return map[string]interface{}{"timeout": true}
+//		}
+//		return nil
+//	})
+//
+// Fields from later classifiers do not replace keys already set by earlier ones.
+// Classifiers run with the logger locked and must not log.
+func (l *Logger) RegisterErrorClassifier(fn func(error) map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.classifiers = append(l.classifiers, fn)
+}
+
+// classifyError appends the classifier fields for err to fields.
+// Must be called with l.mu held.
+func (l *Logger) classifyError(fields []Field, err error) []Field {
+	seen := make(map[string]bool)
+	for _, fn := range l.classifiers {
+		m := fn(err)
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fields = append(fields[:len(fields):len(fields)], classifierField(k, m[k]))
+		}
+	}
+	return fields
+}
+
+// classifyFields appends the classifier fields for every non-nil error field.
+// Must be called with l.mu held.
+func (l *Logger) classifyFields(fields []Field) []Field {
+	if len(l.classifiers) == 0 {
+		return fields
+	}
+	out := fields
+	for _, f := range fields {
+		if f.kind == errorKind && f.err != nil {
+			out = l.classifyError(out, f.err)
+		}
+	}
+	return out
+}
+
+// classifierField converts a classifier value to a typed field where possible.
+func classifierField(key string, v interface{}) Field {
+	switch v := v.(type) {
+	case string:
+		return Str(key, v)
+	case bool:
+		return Bool(key, v)
+	case int:
+		return Int(key, v)
+	case float64:
+		return Float64(key, v)
+	default:
+		return Any(key, v)
+	}
+}
@@ -0,0 +1,194 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// CloudWatch Logs PutLogEvents limits.
+const (
+	cwMaxBatchEvents = 10000
+	cwMaxBatchBytes  = 1048576
+	cwEventOverhead  = 26 // bytes counted per event on top of the message
+	cwMaxEventBytes  = 262144
+	cwMaxBatchSpan   = 24 * time.Hour
+	cwMaxPending     = 10 * cwMaxBatchEvents
+	cwFlushInterval  = 5 * time.Second
+	cwMaxAttempts    = 3
+)
+
+// CWEvent is a single CloudWatch Logs event.
+type CWEvent struct {
+	Timestamp int64 // milliseconds since the Unix epoch
+	Message   string
+}
+
+// CWClient puts log events into a CloudWatch Logs stream. It is implemented by a thin
+// adapter over the AWS SDK, so the SDK is not a dependency of this package.
+// PutLogEvents returns the next sequence token. If the stream expects a different token,
+// the adapter should return an *InvalidSequenceTokenError carrying it, possibly wrapped.
+type CWClient interface {
+	PutLogEvents(group, stream string, events []CWEvent, sequenceToken string) (nextToken string, err error)
+}
+
+// InvalidSequenceTokenError reports that PutLogEvents was called with a stale sequence token.
+type InvalidSequenceTokenError struct {
+	ExpectedToken string
+}
+
+func (e *InvalidSequenceTokenError) Error() string {
+	return "logger: invalid CloudWatch sequence token, expected " + e.ExpectedToken
+}
+
+// cloudWatchSink batches entries and ships them to CloudWatch Logs from a background goroutine.
+type cloudWatchSink struct {
+	client CWClient
+	group  string
+	stream string
+
+	mu      sync.Mutex
+	pending []CWEvent
+	bytes   int
+	token   string
+	sendErr error // error of the latest send, nil once a send succeeds
+	lastErr error // latest send error, reported by Close
+
+	flush     chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewCloudWatchSink returns a sink shipping entries to the CloudWatch Logs stream
+// group/stream through client. Entries are batched within the PutLogEvents limits and
+// sent every 5 seconds, when a batch is full, and on Close; the sequence token is
+// tracked and refreshed when the service reports a stale one. If the service stays
+// unreachable, at most 100000 events are queued and newer ones are dropped. While the
+// latest send has failed, Write returns its error (the entry is still queued), so the
+// sink can be wrapped with NewCircuitBreaker. Attach it with AddSink.
+func NewCloudWatchSink(group, stream string, client CWClient) Sink {
+	s := &cloudWatchSink{
+		client: client,
+		group:  group,
+		stream: stream,
+		flush:  make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Write queues one entry as an event and returns the error of the latest send, if it failed.
+func (s *cloudWatchSink) Write(t time.Time, level LogLevel, entry []byte) error {
+	msg := bytes.TrimRight(entry, "\n")
+	if len(msg) > cwMaxEventBytes-cwEventOverhead {
+		msg = msg[:cwMaxEventBytes-cwEventOverhead]
+		// Do not split a UTF-8 sequence.
+		for len(msg) > 0 {
+			if r, size := utf8.DecodeLastRune(msg); r != utf8.RuneError || size > 1 {
+				break
+			}
+			msg = msg[:len(msg)-1]
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending) >= cwMaxPending {
+		return s.sendErr
+	}
+	s.pending = append(s.pending, CWEvent{Timestamp: t.UnixMilli(), Message: string(msg)})
+	s.bytes += len(msg) + cwEventOverhead
+	if len(s.pending) >= cwMaxBatchEvents || s.bytes >= cwMaxBatchBytes {
+		select {
+		case s.flush <- struct{}{}:
+		default:
+		}
+	}
+	return s.sendErr
+}
+
+// run sends queued events periodically or when a batch fills up, until Close.
+func (s *cloudWatchSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(cwFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			s.send()
+			return
+		case <-ticker.C:
+			s.send()
+		case <-s.flush:
+			s.send()
+		}
+	}
+}
+
+// send ships all queued events in batches within the service limits.
+func (s *cloudWatchSink) send() {
+	s.mu.Lock()
+	events := s.pending
+	s.pending, s.bytes = nil, 0
+	s.mu.Unlock()
+
+	// Events in a batch must be in chronological order.
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Timestamp < events[j].Timestamp })
+	for len(events) > 0 {
+		n, size := 0, 0
+		for n < len(events) && n < cwMaxBatchEvents {
+			sz := len(events[n].Message) + cwEventOverhead
+			if size+sz > cwMaxBatchBytes {
+				break
+			}
+			if time.Duration(events[n].Timestamp-events[0].Timestamp)*time.Millisecond > cwMaxBatchSpan {
+				break
+			}
+			size += sz
+			n++
+		}
+		s.put(events[:n])
+		events = events[n:]
+	}
+}
+
+// put sends one batch, retrying with the expected token if the current one is stale.
+// Batches that still fail are discarded and the error is reported by Write and Close.
+func (s *cloudWatchSink) put(batch []CWEvent) {
+	var err error
+	for attempt := 0; attempt < cwMaxAttempts; attempt++ {
+		var next string
+		next, err = s.client.PutLogEvents(s.group, s.stream, batch, s.token)
+		if err == nil {
+			s.token = next
+			s.mu.Lock()
+			s.sendErr = nil
+			s.mu.Unlock()
+			return
+		}
+		var tokenErr *InvalidSequenceTokenError
+		if !errors.As(err, &tokenErr) {
+			break
+		}
+		s.token = tokenErr.ExpectedToken
+	}
+	s.mu.Lock()
+	s.sendErr, s.lastErr = err, err
+	s.mu.Unlock()
+}
+
+// Close sends the queued events and stops the background goroutine.
+// It returns the last error reported by the client, if any.
+func (s *cloudWatchSink) Close() error {
+	s.closeOnce.Do(func() { close(s.stop) })
+	<-s.done
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastErr
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeCWClient records the batches it accepts and fails with err while it is set; with
+// expect set, calls carrying another token fail with a wrapped token error.
+type fakeCWClient struct {
+	mu      sync.Mutex
+	err     error
+	expect  string
+	batches [][]CWEvent
+}
+
+func (c *fakeCWClient) PutLogEvents(_, _ string, events []CWEvent, token string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return "", c.err
+	}
+	if c.expect != "" && token != c.expect {
+		return "", fmt.Errorf("put: %w", &InvalidSequenceTokenError{ExpectedToken: c.expect})
+	}
+	c.batches = append(c.batches, append([]CWEvent(nil), events...))
+	return "next", nil
+}
+
+func TestCloudWatchWrappedTokenError(t *testing.T) {
+	client := &fakeCWClient{expect: "expected"}
+	s := NewCloudWatchSink("group", "stream", client)
+	_ = s.Write(time.Now(), INFO, []byte("entry\n"))
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if len(client.batches) != 1 || client.batches[0][0].Message != "entry" {
+		t.Errorf("batches = %+v, want the entry sent after the token retry", client.batches)
+	}
+}
+
+func TestCloudWatchWriteReportsSendError(t *testing.T) {
+	errDown := errors.New("service down")
+	client := &fakeCWClient{err: errDown}
+	s := NewCloudWatchSink("group", "stream", client)
+	cw := s.(*cloudWatchSink)
+
+	if err := s.Write(time.Now(), INFO, []byte("first\n")); err != nil {
+		t.Fatalf("Write before any send: %v", err)
+	}
+	cw.send()
+	if err := s.Write(time.Now(), INFO, []byte("second\n")); !errors.Is(err, errDown) {
+		t.Errorf("Write after failed send = %v, want %v", err, errDown)
+	}
+
+	client.mu.Lock()
+	client.err = nil
+	client.mu.Unlock()
+	cw.send()
+	if err := s.Write(time.Now(), INFO, []byte("third\n")); err != nil {
+		t.Errorf("Write after successful send = %v, want nil", err)
+	}
+	if err := s.Close(); !errors.Is(err, errDown) {
+		t.Errorf("Close = %v, want %v", err, errDown)
+	}
+}
@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"os"
+	"strings"
+)
+
+// colorMode is the console color setting chosen with SetColorEnabled.
+type colorMode uint8
+
+const (
+	colorAuto colorMode = iota // decided by NO_COLOR and FORCE_COLOR
+	colorOn
+	colorOff
+)
+
+// SetColorEnabled turns console colors on or off, overriding the NO_COLOR and
+// FORCE_COLOR environment variables.
+//
+// Without it, colors are decided in this order:
+//  1. NO_COLOR set to a non-empty value disables colors;
+//  2. otherwise FORCE_COLOR set to a non-empty value other than "0" or "false" enables
+//     them, also through NewColorWriter on a writer that is not a terminal;
+//  3. otherwise the logger writes colors to the console and NewColorWriter strips them
+//     when its writer is not a terminal.
+//
+// The variables are read when the logger is created (and by NewColorWriter when it is called).
+func (l *Logger) SetColorEnabled(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if enabled {
+		l.colorMode = colorOn
+	} else {
+		l.colorMode = colorOff
+	}
+}
+
+// colorEnabled reports whether console output is colorized.
+// Must be called with l.mu held.
+func (l *Logger) colorEnabled() bool {
+	switch l.colorMode {
+	case colorOn:
+		return true
+	case colorOff:
+		return false
+	}
+	return l.envColor != colorOff
+}
+
+// envColorMode returns the color mode requested by NO_COLOR and FORCE_COLOR.
+func envColorMode() colorMode {
+	if os.Getenv("NO_COLOR") != "" {
+		return colorOff
+	}
+	switch v := strings.ToLower(os.Getenv("FORCE_COLOR")); v {
+	case "", "0", "false":
+		return colorAuto
+	}
+	return colorOn
+}
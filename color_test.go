@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestColorPrecedence(t *testing.T) {
+	on, off := true, false
+	tests := []struct {
+		noColor, forceColor string
+		explicit            *bool // SetColorEnabled argument, nil if not called
+		want                bool
+	}{
+		// Auto-detection: the logger colors its output.
+		{"", "", nil, true},
+		{"", "0", nil, true},
+		{"", "false", nil, true},
+		{"", "1", nil, true},
+		// NO_COLOR beats FORCE_COLOR.
+		{"1", "", nil, false},
+		{"1", "1", nil, false},
+		// SetColorEnabled beats both.
+		{"", "", &off, false},
+		{"", "1", &off, false},
+		{"1", "", &on, true},
+		{"1", "1", &on, true},
+		{"1", "1", &off, false},
+		{"", "", &on, true},
+	}
+	for _, tt := range tests {
+		name := "NO_COLOR=" + tt.noColor + ",FORCE_COLOR=" + tt.forceColor
+		if tt.explicit != nil {
+			name += ",explicit"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("FORCE_COLOR", tt.forceColor)
+			var console bytes.Buffer
+			l := NewLogger(DEBUG, DISABLED)
+			defer l.Close()
+			l.SetConsoleWriter(&console)
+			if tt.explicit != nil {
+				l.SetColorEnabled(*tt.explicit)
+			}
+			l.Info("hi")
+			if got := strings.Contains(console.String(), "\033["); got != tt.want {
+				t.Errorf("colored = %v, want %v: %q", got, tt.want, console.String())
+			}
+		})
+	}
+}
+
+func TestColorWriterEnv(t *testing.T) {
+	tests := []struct {
+		noColor, forceColor string
+		keep                bool
+	}{
+		{"", "", false}, // a buffer is not a terminal
+		{"", "0", false},
+		{"", "false", false},
+		{"", "1", true},
+		{"", "always", true},
+		{"1", "", false},
+		{"1", "1", false},
+	}
+	for _, tt := range tests {
+		t.Run("NO_COLOR="+tt.noColor+",FORCE_COLOR="+tt.forceColor, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("FORCE_COLOR", tt.forceColor)
+			var out bytes.Buffer
+			_, _ = NewColorWriter(&out).Write([]byte(red + "x" + reset))
+			want := "x"
+			if tt.keep {
+				want = red + "x" + reset
+			}
+			if out.String() != want {
+				t.Errorf("got %q, want %q", out.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"io"
+	"os"
+)
+
+// ansiState is the state of the escape sequence parser in stripWriter.
+type ansiState uint8
+
+const (
+	ansiText ansiState = iota // plain text
+	ansiEsc                   // after ESC
+	ansiCSI                   // inside ESC [ ... sequence
+)
+
+// stripWriter removes ANSI escape sequences from the stream before writing it to w.
+// Parser state is kept between writes so sequences split across calls are handled.
+type stripWriter struct {
+	w     io.Writer
+	state ansiState
+	buf   []byte
+}
+
+// NewColorWriter wraps w so that ANSI color codes are kept when w is a terminal
+// and stripped otherwise (e.g. when stdout is redirected to a file). FORCE_COLOR keeps
+// them and NO_COLOR strips them regardless of w, see SetColorEnabled.
+func NewColorWriter(w io.Writer) io.Writer {
+	switch envColorMode() {
+	case colorOn:
+		return w
+	case colorOff:
+		return &stripWriter{w: w}
+	}
+	if isTerminal(w) {
+		return w
+	}
+	return &stripWriter{w: w}
+}
+
+// Write implements io.Writer.
+func (s *stripWriter) Write(p []byte) (int, error) {
+	s.buf = s.buf[:0]
+	for _, c := range p {
+		switch s.state {
+		case ansiText:
+			if c == 0x1b {
+				s.state = ansiEsc
+			} else {
+				s.buf = append(s.buf, c)
+			}
+		case ansiEsc:
+			if c == '[' {
+				s.state = ansiCSI
+			} else {
+				// Two-byte sequence (ESC + final byte).
+				s.state = ansiText
+			}
+		case ansiCSI:
+			// Parameter and intermediate bytes continue the sequence; a final byte ends it.
+			if c >= 0x40 && c <= 0x7e {
+				s.state = ansiText
+			}
+		}
+	}
+	if _, err := s.w.Write(s.buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// isTerminal reports whether w is a character device such as a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"context"
+	"sync"
+)
+
+// contextKey is the context key under which a *Logger is stored.
+type contextKey struct{}
+
+var (
+	defaultOnce   sync.Once
+	defaultLogger *Logger
+)
+
+// NewContext returns a copy of ctx carrying l.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx by NewContext.
+// If none is set, it returns a shared default logger writing INFO and above to the console only.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(contextKey{}).(*Logger); ok && l != nil {
+		return l
+	}
+	defaultOnce.Do(func() {
+		defaultLogger = NewLogger(INFO, DISABLED)
+	})
+	return defaultLogger
+}
+
+// CopyFields returns dst carrying the logger, trace ID (WithTraceID) and override level
+// (WithLevel) stored in src, so work started with an unrelated context (e.g.
+// context.Background() for a detached job) keeps the request-scoped fields. Values src
+// does not carry are left as they are in dst.
+func CopyFields(dst, src context.Context) context.Context {
+	if l, ok := src.Value(contextKey{}).(*Logger); ok && l != nil {
+		dst = NewContext(dst, l)
+	}
+	if id, ok := TraceIDFromContext(src); ok {
+		dst = WithTraceID(dst, id)
+	}
+	if level, ok := LevelFromContext(src); ok {
+		dst = WithLevel(dst, level)
+	}
+	return dst
+}
+
+// GoWithContext runs fn in a new goroutine with ctx, so logs written through
+// FromContext inside fn carry the caller's request-scoped fields. The trace ID stored in
+// ctx, if any, is also bound to the goroutine (see SetTraceID) while fn runs, so it is
+// logged by the plain methods as well. Panics in fn are recovered and logged at FAIL
+// level by that logger.
+func GoWithContext(ctx context.Context, fn func(ctx context.Context)) {
+	l := FromContext(ctx)
+	id, hasID := TraceIDFromContext(ctx)
+	go func() {
+		if hasID {
+			SetTraceID(id)
+			defer ClearTraceID()
+		}
+		defer l.RecoverAndContinue()
+		fn(ctx)
+	}()
+}
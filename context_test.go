@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCopyFields(t *testing.T) {
+	l, _ := newTestLogger(t)
+	src := WithLevel(WithTraceID(NewContext(context.Background(), l), "req-1"), ERROR)
+	ctx := CopyFields(context.Background(), src)
+
+	if got := FromContext(ctx); got != l {
+		t.Errorf("logger not copied")
+	}
+	if id, ok := TraceIDFromContext(ctx); !ok || id != "req-1" {
+		t.Errorf("trace ID = %q, %v, want req-1", id, ok)
+	}
+	if level, ok := LevelFromContext(ctx); !ok || level != ERROR {
+		t.Errorf("level = %v, %v, want ERROR", level, ok)
+	}
+
+	empty := context.Background()
+	if got := CopyFields(empty, context.Background()); got != empty {
+		t.Errorf("CopyFields without values changed dst")
+	}
+}
+
+func TestGoWithContextTraceID(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(ctx context.Context)
+	}{
+		{"plain method", func(ctx context.Context) { FromContext(ctx).Info("work") }},
+		{"ctx method", func(ctx context.Context) { FromContext(ctx).InfoCtx(ctx, "work") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console := newTestLogger(t)
+			ctx := CopyFields(context.Background(), WithTraceID(NewContext(context.Background(), l), "req-1"))
+			done := make(chan struct{})
+			GoWithContext(ctx, func(ctx context.Context) {
+				defer close(done)
+				tt.log(ctx)
+			})
+			<-done
+			l.Flush()
+
+			out := console.String()
+			if strings.Count(out, "trace_id=req-1") != 1 {
+				t.Errorf("want one trace_id=req-1 field, got %q", out)
+			}
+		})
+	}
+}
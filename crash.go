@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// defaultCrashLogEntries is the number of entries kept by SetCrashLog by default.
+const defaultCrashLogEntries = 200
+
+// crashLog keeps the most recent entries in memory for a crash dump.
+type crashLog struct {
+	path    string
+	entries [][]byte // ring of rendered entries
+	next    int
+	full    bool
+}
+
+// SetCrashLog keeps the last n entries (200 if n <= 0) in memory, at every level and
+// whatever the outputs' levels, and writes them to the file at path together with the
+// panic value when Recover re-panics (see SetRepanic), i.e. when a panic is
+// about to crash the program. Buffered file output is flushed at the same time. To
+// cover the whole program, defer log.Recover() at the top of main and of goroutines.
+// An empty path disables it.
+func (l *Logger) SetCrashLog(path string, n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if path == "" {
+		l.crash = nil
+		return
+	}
+	if n <= 0 {
+		n = defaultCrashLogEntries
+	}
+	l.crash = &crashLog{path: path, entries: make([][]byte, n)}
+}
+
+// recordCrashEntry renders e in the text format into the ring, overwriting the oldest entry.
+// Must be called with l.mu held.
+func (l *Logger) recordCrashEntry(e *entry) {
+	c := l.crash
+	c.entries[c.next] = l.appendText(c.entries[c.next][:0], e, false)
+	c.next++
+	if c.next == len(c.entries) {
+		c.next, c.full = 0, true
+	}
+}
+
+// writeCrashLog writes the panic value and the recent entries, the last of which is the
+// logged panic with its stack, to the crash log.
+// Must be called with l.mu held.
+func (l *Logger) writeCrashLog(t time.Time, r interface{}) error {
+	_ = l.syncFile()
+	c := l.crash
+	buf := []byte(fmt.Sprintf("crash at %s: panic: %v\n\nlast entries:\n", t.Format(time.RFC3339Nano), r))
+	if c.full {
+		for _, p := range c.entries[c.next:] {
+			buf = append(append(buf, p...), '\n')
+		}
+	}
+	for _, p := range c.entries[:c.next] {
+		buf = append(append(buf, p...), '\n')
+	}
+
+	file, err := openFileFlag(c.path, os.O_TRUNC)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(buf); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
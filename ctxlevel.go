@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"context"
+	"time"
+)
+
+// levelKey is the context key under which an override level is stored.
+type levelKey struct{}
+
+// WithLevel returns a copy of ctx carrying an override level. Calls made through the
+// *Ctx methods with that context use level instead of the console and file levels, so a
+// single job or request can be made more (or less) verbose without changing the logger.
+// Outputs that are DISABLED stay disabled.
+func WithLevel(ctx context.Context, level LogLevel) context.Context {
+	return context.WithValue(ctx, levelKey{}, level)
+}
+
+// LevelFromContext returns the override level stored in ctx by WithLevel, if any.
+func LevelFromContext(ctx context.Context) (LogLevel, bool) {
+	level, ok := ctx.Value(levelKey{}).(LogLevel)
+	return level, ok
+}
+
+// LogCtx is like Log but honors the override level stored in ctx by WithLevel and
+// logs the trace ID stored by WithTraceID. Without either it behaves exactly like Log.
+func (l *Logger) LogCtx(ctx context.Context, level LogLevel, format string, args ...interface{}) {
+	msg, fields := l.formatArgs(format, args)
+	if id, ok := TraceIDFromContext(ctx); ok {
+		fields = append(fields, Str(traceIDField, id))
+	}
+	min, ok := LevelFromContext(ctx)
+	if !ok {
+		l.output(level, msg, fields)
+		return
+	}
+
+	t := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fields, ok = l.sample(level, msg, fields)
+	if !ok {
+		return
+	}
+	e := l.newEntry(t, level, msg, fields)
+	e.minLevel, e.hasMinLevel = min, true
+	l.writeEntry(&e)
+}
+
+// DebugCtx logs a message at DEBUG level, honoring the override level in ctx.
+func (l *Logger) DebugCtx(ctx context.Context, format string, args ...interface{}) {
+	l.LogCtx(ctx, DEBUG, format, args...)
+}
+
+// InfoCtx logs a message at INFO level, honoring the override level in ctx.
+func (l *Logger) InfoCtx(ctx context.Context, format string, args ...interface{}) {
+	l.LogCtx(ctx, INFO, format, args...)
+}
+
+// ErrorCtx logs a message at ERROR level, honoring the override level in ctx.
+func (l *Logger) ErrorCtx(ctx context.Context, format string, args ...interface{}) {
+	l.LogCtx(ctx, ERROR, format, args...)
+}
+
+// SuccessCtx logs a message at SUCCESS level, honoring the override level in ctx.
+func (l *Logger) SuccessCtx(ctx context.Context, format string, args ...interface{}) {
+	l.LogCtx(ctx, SUCCESS, format, args...)
+}
+
+// FailCtx logs a message at FAIL level, honoring the override level in ctx.
+func (l *Logger) FailCtx(ctx context.Context, format string, args ...interface{}) {
+	l.LogCtx(ctx, FAIL, format, args...)
+}
+
+// overrideLevel returns the threshold for an output configured at configured when an
+// override level is in effect.
+func overrideLevel(configured, override LogLevel) LogLevel {
+	if configured == DISABLED {
+		return DISABLED
+	}
+	return override
+}
@@ -0,0 +1,298 @@
+package logger
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// diffContext is the number of unchanged lines shown around each change.
+	diffContext = 3
+	// maxDiffCells bounds the LCS table; larger inputs are diffed as a full replacement.
+	maxDiffCells = 1 << 20
+	// defaultDiffLineLimit is the default maximum number of lines rendered by Diff.
+	defaultDiffLineLimit = 200
+	// maxDiffLineLen is the longest line rendered by Diff; longer lines are cut.
+	maxDiffLineLen = 1024
+)
+
+// diffLine is one line of a unified diff: kind is ' ', '-', '+' or '@' for hunk headers.
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// diffData is a line diff attached to an entry by Diff.
+type diffData struct {
+	lines   []diffLine
+	added   int
+	removed int
+	omitted int // lines beyond the limit
+}
+
+// SetDiffLineLimit sets the maximum number of lines Diff renders, hunk headers included
+// (200 by default); the rest is summarized as "... N more lines". Lines longer than 1 KiB
+// are cut. A negative limit renders all lines.
+func (l *Logger) SetDiffLineLimit(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.diffLineLimit = n
+}
+
+// Diff logs a line diff between old and new under label, e.g. for configuration reloads.
+// Text output renders it as a unified diff, colorized on the console (additions green,
+// removals red); JSON output carries it in a "diff" string field. The message reports
+// the number of added and removed lines and is "label (no changes)" for equal inputs.
+func (l *Logger) Diff(level LogLevel, label, old, new string) {
+	t := time.Now()
+
+	d := computeDiff(splitLines(old), splitLines(new))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	msg := label + " (no changes)"
+	if len(d.lines) > 0 {
+		msg = label + " (+" + strconv.Itoa(d.added) + " -" + strconv.Itoa(d.removed) + ")"
+	}
+	e := l.newEntry(t, level, msg, nil)
+	if len(d.lines) > 0 {
+		limit := l.diffLineLimit
+		if limit == 0 {
+			limit = defaultDiffLineLimit
+		}
+		d.truncate(limit)
+		e.diff = d
+	}
+	l.writeEntry(&e)
+}
+
+// truncate keeps at most limit lines (all if limit < 0) and cuts lines longer than
+// maxDiffLineLen.
+func (d *diffData) truncate(limit int) {
+	if limit >= 0 && len(d.lines) > limit {
+		d.omitted = len(d.lines) - limit
+		d.lines = d.lines[:limit]
+	}
+	for i, ln := range d.lines {
+		if len(ln.text) > maxDiffLineLen {
+			cut := maxDiffLineLen
+			for cut > 0 && !utf8.RuneStart(ln.text[cut]) {
+				cut--
+			}
+			d.lines[i].text = ln.text[:cut] + "... (" + strconv.Itoa(len(ln.text)-cut) + " more bytes)"
+		}
+	}
+}
+
+// splitLines splits s into lines, ignoring a trailing newline.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// computeDiff returns the unified diff of a and b. Common leading and trailing lines are
+// trimmed before the LCS; if the remainder is too large it is treated as replaced.
+func computeDiff(a, b []string) *diffData {
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+
+	ops := make([]diffLine, 0, len(a)+len(b)-pre-suf)
+	for _, s := range a[:pre] {
+		ops = append(ops, diffLine{' ', s})
+	}
+	ops = appendLCSOps(ops, a[pre:len(a)-suf], b[pre:len(b)-suf])
+	for _, s := range a[len(a)-suf:] {
+		ops = append(ops, diffLine{' ', s})
+	}
+	return buildHunks(ops)
+}
+
+// appendLCSOps appends the edit script turning a into b to ops.
+func appendLCSOps(ops []diffLine, a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 || n > maxDiffCells/m {
+		for _, s := range a {
+			ops = append(ops, diffLine{'-', s})
+		}
+		for _, s := range b {
+			ops = append(ops, diffLine{'+', s})
+		}
+		return ops
+	}
+
+	// lcs[i*(m+1)+j] is the LCS length of a[i:] and b[j:].
+	lcs := make([]int32, (n+1)*(m+1))
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j+1] + 1
+			case lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]:
+				lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j]
+			default:
+				lcs[i*(m+1)+j] = lcs[i*(m+1)+j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]:
+			ops = append(ops, diffLine{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffLine{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffLine{'+', b[j]})
+	}
+	return ops
+}
+
+// buildHunks groups an edit script into hunks with diffContext lines of context,
+// each preceded by an "@@ -a,b +c,d @@" header.
+func buildHunks(ops []diffLine) *diffData {
+	d := &diffData{}
+	for k := 0; k < len(ops); {
+		// Find the next change.
+		for k < len(ops) && ops[k].kind == ' ' {
+			k++
+		}
+		if k == len(ops) {
+			break
+		}
+		start := k - diffContext
+		if start < 0 {
+			start = 0
+		}
+		// Extend the hunk while changes are within 2*diffContext lines of each other.
+		end := k
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*diffContext {
+				end += diffContext
+				if end > len(ops) {
+					end = len(ops)
+				}
+				break
+			}
+			end = run
+		}
+
+		oldStart, newStart := 1, 1
+		for _, op := range ops[:start] {
+			if op.kind != '+' {
+				oldStart++
+			}
+			if op.kind != '-' {
+				newStart++
+			}
+		}
+		oldLen, newLen := 0, 0
+		for _, op := range ops[start:end] {
+			switch op.kind {
+			case '-':
+				oldLen++
+				d.removed++
+			case '+':
+				newLen++
+				d.added++
+			default:
+				oldLen++
+				newLen++
+			}
+		}
+		d.lines = append(d.lines, diffLine{'@', "@ -" + hunkRange(oldStart, oldLen) + " +" + hunkRange(newStart, newLen) + " @@"})
+		d.lines = append(d.lines, ops[start:end]...)
+		k = end
+	}
+	return d
+}
+
+// hunkRange formats a unified diff range; empty ranges start at the preceding line.
+func hunkRange(start, n int) string {
+	if n == 0 {
+		start--
+	}
+	return strconv.Itoa(start) + "," + strconv.Itoa(n)
+}
+
+// appendText appends the diff as lines, each preceded by a newline. With sanitize,
+// control characters in the lines are escaped; with color, additions are green,
+// removals red and hunk headers blue.
+func (d *diffData) appendText(buf []byte, sanitize, color bool) []byte {
+	for _, ln := range d.lines {
+		buf = append(buf, '\n')
+		code := ""
+		if color {
+			switch ln.kind {
+			case '+':
+				code = green
+			case '-':
+				code = red
+			case '@':
+				code = blue
+			}
+		}
+		buf = append(buf, code...)
+		buf = append(buf, ln.kind)
+		if sanitize {
+			buf = appendSanitized(buf, ln.text, false)
+		} else {
+			buf = append(buf, ln.text...)
+		}
+		if code != "" {
+			buf = append(buf, reset...)
+		}
+	}
+	if d.omitted > 0 {
+		buf = append(buf, "\n... "...)
+		buf = strconv.AppendInt(buf, int64(d.omitted), 10)
+		buf = append(buf, " more lines"...)
+	}
+	return buf
+}
+
+// appendJSON appends the diff as a JSON string in unified format.
+func (d *diffData) appendJSON(buf []byte) []byte {
+	var sb strings.Builder
+	for i, ln := range d.lines {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteByte(ln.kind)
+		sb.WriteString(ln.text)
+	}
+	if d.omitted > 0 {
+		sb.WriteString("\n... " + strconv.Itoa(d.omitted) + " more lines")
+	}
+	return appendJSONString(buf, sb.String())
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// numberedLines returns n lines "prefix0" to "prefix<n-1>" joined by newlines.
+func numberedLines(prefix string, n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = prefix + strconv.Itoa(i)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestDiffLineLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantLines int // rendered diff lines, hunk header included
+		omitted   int
+	}{
+		{"default", 0, defaultDiffLineLimit, 2*300 + 1 - defaultDiffLineLimit},
+		{"custom", 10, 10, 2*300 + 1 - 10},
+		{"unlimited", -1, 2*300 + 1, 0},
+		{"above size", 1000, 2*300 + 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console := newTestLogger(t)
+			l.SetDiffLineLimit(tt.limit)
+			l.Diff(INFO, "cfg", numberedLines("old", 300), numberedLines("new", 300))
+
+			lines := strings.Split(strings.TrimSuffix(console.String(), "\n"), "\n")
+			if !strings.HasSuffix(lines[0], "| INFO | cfg (+300 -300)") {
+				t.Fatalf("message = %q, want counts of the whole diff", lines[0])
+			}
+			lines = lines[1:]
+			if tt.omitted > 0 {
+				if last := lines[len(lines)-1]; last != "... "+strconv.Itoa(tt.omitted)+" more lines" {
+					t.Errorf("last line = %q, want the omitted count %d", last, tt.omitted)
+				}
+				lines = lines[:len(lines)-1]
+			}
+			if len(lines) != tt.wantLines {
+				t.Errorf("rendered %d diff lines, want %d", len(lines), tt.wantLines)
+			}
+		})
+	}
+}
+
+func TestDiffLongLine(t *testing.T) {
+	l, console := newTestLogger(t)
+	long := strings.Repeat("é", maxDiffLineLen)
+	l.Diff(INFO, "cfg", "a", long)
+	out := console.String()
+	if len(out) > 2*maxDiffLineLen+200 || !strings.Contains(out, "more bytes)") {
+		t.Errorf("long line not cut: %d bytes", len(out))
+	}
+	if !strings.Contains(out, "+"+strings.Repeat("é", maxDiffLineLen/2)+"...") {
+		t.Error("line not cut at a rune boundary")
+	}
+}
+
+func TestDiffTooLargeForLCS(t *testing.T) {
+	// 2048 x 1024 lines exceed maxDiffCells and are diffed as a full replacement.
+	a := strings.Split(numberedLines("a", 2048), "\n")
+	b := append([]string{"a0"}, strings.Split(numberedLines("b", 1023), "\n")...)
+	d := &diffData{}
+	for _, op := range appendLCSOps(nil, a, b) {
+		switch op.kind {
+		case '-':
+			d.removed++
+		case '+':
+			d.added++
+		}
+	}
+	if d.removed != len(a) || d.added != len(b) {
+		t.Errorf("got -%d +%d, want a full replacement -%d +%d", d.removed, d.added, len(a), len(b))
+	}
+}
@@ -0,0 +1,95 @@
+package logger
+
+import "time"
+
+// durabilityMode selects when file output is synced to stable storage.
+type durabilityMode int
+
+const (
+	osBuffered durabilityMode = iota
+	syncEach
+	syncInterval
+)
+
+// DurabilityPolicy controls how aggressively file output is forced to disk.
+type DurabilityPolicy struct {
+	mode     durabilityMode
+	interval time.Duration
+}
+
+// Durability policies, from fastest to most durable:
+//
+//   - PolicyOSBuffered (default): writes go to the OS page cache and reach the disk
+//     whenever the OS decides. Lowest latency; recent entries can be lost on power loss
+//     or a kernel crash (but not on a process crash).
+//   - PolicySyncInterval(d): additionally fsyncs the file every d. Bounds the loss window
+//     to roughly d at the cost of a periodic sync.
+//   - PolicySyncEach: flushes and fsyncs after every entry. Nothing acknowledged is lost,
+//     but every log call pays a disk round trip; use it for audit trails.
+var (
+	PolicyOSBuffered = DurabilityPolicy{mode: osBuffered}
+	PolicySyncEach   = DurabilityPolicy{mode: syncEach}
+)
+
+// PolicySyncInterval returns a policy that fsyncs the log file every d.
+func PolicySyncInterval(d time.Duration) DurabilityPolicy {
+	if d <= 0 {
+		return PolicySyncEach
+	}
+	return DurabilityPolicy{mode: syncInterval, interval: d}
+}
+
+// syncer is implemented by file writers that can be synced to disk (e.g. *os.File, *RotatingFile).
+type syncer interface {
+	Sync() error
+}
+
+// SetDurabilityPolicy sets when file output is synced to disk (see DurabilityPolicy).
+func (l *Logger) SetDurabilityPolicy(p DurabilityPolicy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopSync()
+	l.durability = p
+	if p.mode == syncInterval {
+		l.syncStop = make(chan struct{})
+		go l.syncLoop(p.interval, l.syncStop)
+	}
+}
+
+// syncLoop syncs the file every interval until stop is closed.
+func (l *Logger) syncLoop(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			_ = l.syncFile()
+			l.mu.Unlock()
+		}
+	}
+}
+
+// syncFile flushes buffered file output and fsyncs the file writer if it supports it.
+// Must be called with l.mu held.
+func (l *Logger) syncFile() error {
+	if err := l.flushFile(); err != nil {
+		return err
+	}
+	if s, ok := l.file.(syncer); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+// stopSync stops the background sync goroutine, if any.
+// Must be called with l.mu held.
+func (l *Logger) stopSync() {
+	if l.syncStop != nil {
+		close(l.syncStop)
+		l.syncStop = nil
+	}
+}
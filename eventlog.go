@@ -0,0 +1,43 @@
+package logger
+
+// Windows event types used when reporting to the Event Log.
+const (
+	eventTypeError       uint16 = 0x0001
+	eventTypeWarning     uint16 = 0x0002
+	eventTypeInformation uint16 = 0x0004
+)
+
+// SetWindowsEventLog additionally reports INFO and above to the Windows Event Log
+// under the given event source. ERROR is reported as error events, FAIL as warning
+// events and INFO and SUCCESS as information events; DEBUG is never reported.
+// The source should be registered beforehand (e.g. by the service installer)
+// for the Event Viewer to display messages without a missing-description notice.
+// On platforms other than Windows it returns an error.
+func (l *Logger) SetWindowsEventLog(source string) error {
+	ev, err := openEventLog(source)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.eventLog != nil {
+		l.eventLog.close()
+	}
+	l.eventLog = ev
+	return nil
+}
+
+// eventType maps a level to the Windows event type, reporting false for levels that are not sent.
+func eventType(level LogLevel) (uint16, bool) {
+	switch level {
+	case ERROR:
+		return eventTypeError, true
+	case FAIL:
+		return eventTypeWarning, true
+	case INFO, SUCCESS:
+		return eventTypeInformation, true
+	default:
+		return 0, false
+	}
+}
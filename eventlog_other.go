@@ -0,0 +1,17 @@
+//go:build !windows
+
+package logger
+
+import "errors"
+
+// eventLog is unavailable outside Windows.
+type eventLog struct{}
+
+// openEventLog always fails outside Windows.
+func openEventLog(source string) (*eventLog, error) {
+	return nil, errors.New("windows event log is not supported on this platform")
+}
+
+func (e *eventLog) report(etype uint16, msg string) error { return nil }
+
+func (e *eventLog) close() error { return nil }
@@ -0,0 +1,68 @@
+//go:build windows
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	advapi32                  = syscall.NewLazyDLL("advapi32.dll")
+	procRegisterEventSourceW  = advapi32.NewProc("RegisterEventSourceW")
+	procDeregisterEventSource = advapi32.NewProc("DeregisterEventSource")
+	procReportEventW          = advapi32.NewProc("ReportEventW")
+)
+
+// eventLog is a handle to a registered Windows event source.
+type eventLog struct {
+	handle uintptr
+}
+
+// openEventLog registers the event source on the local machine.
+func openEventLog(source string) (*eventLog, error) {
+	src, err := syscall.UTF16PtrFromString(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event source %q: %w", source, err)
+	}
+	h, _, callErr := procRegisterEventSourceW.Call(0, uintptr(unsafe.Pointer(src)))
+	if h == 0 {
+		return nil, fmt.Errorf("failed to register event source %q: %w", source, callErr)
+	}
+	return &eventLog{handle: h}, nil
+}
+
+// report writes msg as a single event of the given type.
+func (e *eventLog) report(etype uint16, msg string) error {
+	m, err := syscall.UTF16PtrFromString(strings.ReplaceAll(msg, "\x00", ""))
+	if err != nil {
+		return err
+	}
+	strs := [1]*uint16{m}
+	r, _, callErr := procReportEventW.Call(
+		e.handle,
+		uintptr(etype),
+		0, // category
+		1, // event ID
+		0, // user SID
+		1, // number of strings
+		0, // raw data size
+		uintptr(unsafe.Pointer(&strs[0])),
+		0, // raw data
+	)
+	if r == 0 {
+		return callErr
+	}
+	return nil
+}
+
+// close deregisters the event source.
+func (e *eventLog) close() error {
+	r, _, callErr := procDeregisterEventSource.Call(e.handle)
+	if r == 0 {
+		return callErr
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// defaultEventInterval is the report interval used until SetEventReportInterval is called.
+const defaultEventInterval = time.Minute
+
+// CountEvent increments the counter for name. Counters are reported at INFO level every
+// report interval (one minute by default, see SetEventReportInterval) as
+// "name: N events in last interval (R/s)" and reset; names without events since the
+// last report are omitted. The reporter is started by the first call and stopped by Close.
+func (l *Logger) CountEvent(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.eventInterval < 0 {
+		return
+	}
+	if l.eventCounts == nil {
+		l.eventCounts = make(map[string]uint64)
+	}
+	l.eventCounts[name]++
+	if l.eventStop == nil {
+		l.startEventReporter()
+	}
+}
+
+// SetEventReportInterval sets how often CountEvent counters are reported.
+// A running reporter is restarted with the new interval. A non-positive d disables
+// reporting and discards pending counts.
+func (l *Logger) SetEventReportInterval(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if d <= 0 {
+		d = -1
+	}
+	l.setEventInterval(d)
+}
+
+// setEventInterval applies an event report interval, 0 selecting the default.
+// Must be called with l.mu held.
+func (l *Logger) setEventInterval(d time.Duration) {
+	if d == l.eventInterval {
+		return
+	}
+	l.eventInterval = d
+	if d < 0 {
+		l.stopEventReporter()
+		l.eventCounts = nil
+		return
+	}
+	if l.eventStop != nil {
+		l.stopEventReporter()
+		l.startEventReporter()
+	}
+}
+
+// startEventReporter starts the background goroutine reporting event counters.
+// Must be called with l.mu held.
+func (l *Logger) startEventReporter() {
+	interval := l.eventInterval
+	if interval == 0 {
+		interval = defaultEventInterval
+	}
+	l.eventStop = make(chan struct{})
+	l.eventSince = time.Now()
+	go l.eventLoop(interval, l.eventStop)
+}
+
+// stopEventReporter stops the event reporter, if any.
+// Must be called with l.mu held.
+func (l *Logger) stopEventReporter() {
+	if l.eventStop != nil {
+		close(l.eventStop)
+		l.eventStop = nil
+	}
+}
+
+// eventLoop reports event counters every interval until stop is closed.
+func (l *Logger) eventLoop(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case t := <-ticker.C:
+			l.mu.Lock()
+			// A tick may race with stopping; the reporter that replaced this one owns the counters.
+			if l.eventStop == stop {
+				l.reportEvents(t)
+			}
+			l.mu.Unlock()
+		}
+	}
+}
+
+// reportEvents logs and resets the event counters.
+// Must be called with l.mu held.
+func (l *Logger) reportEvents(t time.Time) {
+	elapsed := t.Sub(l.eventSince).Seconds()
+	l.eventSince = t
+	if len(l.eventCounts) == 0 {
+		return
+	}
+	names := make([]string, 0, len(l.eventCounts))
+	for name := range l.eventCounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		n := l.eventCounts[name]
+		rate := 0.0
+		if elapsed > 0 {
+			rate = float64(n) / elapsed
+		}
+		l.emit(t, INFO, fmt.Sprintf("%s: %d events in last interval (%.2f/s)", name, n, rate), nil)
+	}
+	l.eventCounts = make(map[string]uint64, len(names))
+}
@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"strconv"
+	"time"
+)
+
+// everySite is the throttling state of one LogEvery call site.
+type everySite struct {
+	last       time.Time
+	suppressed int
+}
+
+// LogEvery logs a formatted message at the given level at most once per d for each call
+// site (file and line of the caller), however its arguments vary, e.g. for a hot warning
+// in a loop. When calls were skipped since the last entry of the site, the next entry
+// carries their number in a "suppressed" field.
+func (l *Logger) LogEvery(level LogLevel, d time.Duration, format string, args ...interface{}) {
+	t := time.Now()
+	site := "???"
+	if frame, ok := callerFrame(); ok {
+		site = frame.File + ":" + strconv.Itoa(frame.Line)
+	}
+
+	l.mu.Lock()
+	s := l.everySites[site]
+	if s == nil {
+		if l.everySites == nil {
+			l.everySites = make(map[string]*everySite)
+		}
+		s = &everySite{}
+		l.everySites[site] = s
+	} else if t.Sub(s.last) < d {
+		s.suppressed++
+		l.mu.Unlock()
+		return
+	}
+	s.last = t
+	suppressed := s.suppressed
+	s.suppressed = 0
+	l.mu.Unlock()
+
+	msg, fields := l.formatArgs(format, args)
+	if suppressed > 0 {
+		fields = append(fields, Int("suppressed", suppressed))
+	}
+	l.output(level, msg, fields)
+}
+
+// DebugEvery logs a message at DEBUG level at most once per d for each call site.
+func (l *Logger) DebugEvery(d time.Duration, format string, args ...interface{}) {
+	l.LogEvery(DEBUG, d, format, args...)
+}
+
+// InfoEvery logs a message at INFO level at most once per d for each call site.
+func (l *Logger) InfoEvery(d time.Duration, format string, args ...interface{}) {
+	l.LogEvery(INFO, d, format, args...)
+}
+
+// SuccessEvery logs a message at SUCCESS level at most once per d for each call site.
+func (l *Logger) SuccessEvery(d time.Duration, format string, args ...interface{}) {
+	l.LogEvery(SUCCESS, d, format, args...)
+}
+
+// FailEvery logs a message at FAIL level at most once per d for each call site.
+func (l *Logger) FailEvery(d time.Duration, format string, args ...interface{}) {
+	l.LogEvery(FAIL, d, format, args...)
+}
+
+// ErrorEvery logs a message at ERROR level at most once per d for each call site.
+func (l *Logger) ErrorEvery(d time.Duration, format string, args ...interface{}) {
+	l.LogEvery(ERROR, d, format, args...)
+}
@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"os"
+	"runtime"
+)
+
+// Exit closes l (see Close) and terminates the program with the given status code.
+// os.Exit does not run deferred calls, so a deferred Close is skipped by it; call
+// l.Exit instead to keep buffered and queued entries.
+func (l *Logger) Exit(code int) {
+	l.Close()
+	os.Exit(code)
+}
+
+// setFinalizer arranges for buffered file output of c to be flushed if it is garbage
+// collected without Close. This is only a safety net: finalizers run at the discretion
+// of the garbage collector, never on program exit, and not at all while background
+// work (such as the periodic syncs of SetDurabilityPolicy with PolicySyncInterval) still
+// references the logger. Use Close, or Exit instead of os.Exit.
+func setFinalizer(c *core) {
+	runtime.SetFinalizer(c, func(c *core) {
+		l := &Logger{core: c}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		_ = l.flushFile()
+	})
+}
@@ -0,0 +1,34 @@
+package logger
+
+import "os"
+
+// SetFallbackToStderr configures whether entries are written to os.Stderr when the logger
+// has no destination at all: no console writer (see SetConsoleWriter(nil)), no file,
+// per-level file, sink, Unix socket, network collector (SetNetworkOutput), journald or
+// event log output. Entries must still meet the console level, so a
+// logger silenced with DISABLED levels stays silent. Enabled by default, to avoid losing
+// logs to a forgotten setup step.
+func (l *Logger) SetFallbackToStderr(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fallbackStderr = enabled
+}
+
+// hasOutputs reports whether any destination is configured.
+// Must be called with l.mu held.
+func (l *Logger) hasOutputs() bool {
+	return l.console != nil || l.file != nil || len(l.levelFiles) > 0 || len(l.sinks) > 0 ||
+		l.socket != nil || l.netOutput != nil || l.journal != nil || l.eventLog != nil
+}
+
+// writeFallback writes e to os.Stderr if no destination is configured and reports whether
+// it did.
+// Must be called with l.mu held.
+func (l *Logger) writeFallback(buf *[]byte, e *entry) bool {
+	if !l.fallbackStderr || l.hasOutputs() || !(e.audit || l.shouldLog(e.level, l.consoleLevel)) {
+		return false
+	}
+	*buf = l.format((*buf)[:0], e, l.consoleFormat, false)
+	_, _ = os.Stderr.Write(*buf)
+	return true
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"net"
+	"testing"
+)
+
+func TestHasOutputs(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ln.Close()
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, l *Logger)
+		want  bool
+	}{
+		{"none", func(t *testing.T, l *Logger) {}, false},
+		{"console", func(t *testing.T, l *Logger) { l.SetConsoleWriter(&recordWriter{}) }, true},
+		{"file", func(t *testing.T, l *Logger) { l.SetFileWriter(&recordWriter{}) }, true},
+		{"sink", func(t *testing.T, l *Logger) { l.AddSink(&recordSink{}, DEBUG, FormatText) }, true},
+		{"network", func(t *testing.T, l *Logger) {
+			if err := l.SetNetworkOutput("tcp", ln.Addr().String()); err != nil {
+				t.Fatal(err)
+			}
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLogger(DEBUG, DISABLED)
+			defer l.Close()
+			l.SetConsoleWriter(nil)
+			tt.setup(t, l)
+			l.mu.Lock()
+			got := l.hasOutputs()
+			l.mu.Unlock()
+			if got != tt.want {
+				t.Errorf("hasOutputs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// recordWriter is an io.Writer keeping what is written to it.
+type recordWriter struct{ data []byte }
+
+func (w *recordWriter) Write(p []byte) (int, error) {
+	w.data = append(w.data, p...)
+	return len(p), nil
+}
@@ -0,0 +1,259 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// fieldKind identifies the type of value stored in a Field.
+type fieldKind uint8
+
+const (
+	stringKind fieldKind = iota
+	intKind
+	boolKind
+	durationKind
+	errorKind
+	floatKind
+	anyKind
+	bytesKind
+)
+
+// Field is a strongly-typed key/value pair attached to a log entry.
+// Fields are built with the typed constructors (Str, Int, Float64, Bool, Dur, Bytes, Err)
+// so that no reflection or map allocation is needed when logging; Any covers
+// arbitrary values at the cost of JSON encoding.
+//
+// Text output writes fields sorted by key, so lines are stable and diffable; fields with
+// the same key keep the order below. JSON and the other structured outputs write them in
+// the order they were collected: SetBuildInfo fields, fields of WithFields parents, the
+// call's own fields in the order given, the sampler's "dropped" count, the goroutine's
+// trace_id and the error classifier fields (sorted by key per error). With a field
+// allowlist, dropped_fields comes last. The nested keys of Any values are always sorted.
+type Field struct {
+	Key  string
+	kind fieldKind
+	str  string
+	num  int64
+	err  error
+	any  interface{}
+}
+
+// Str returns a string field.
+func Str(key, val string) Field {
+	return Field{Key: key, kind: stringKind, str: val}
+}
+
+// Int returns an integer field.
+func Int(key string, val int) Field {
+	return Field{Key: key, kind: intKind, num: int64(val)}
+}
+
+// Float64 returns a floating-point field.
+func Float64(key string, val float64) Field {
+	return Field{Key: key, kind: floatKind, num: int64(math.Float64bits(val))}
+}
+
+// Bool returns a boolean field.
+func Bool(key string, val bool) Field {
+	var n int64
+	if val {
+		n = 1
+	}
+	return Field{Key: key, kind: boolKind, num: n}
+}
+
+// Dur returns a time.Duration field. Text output uses time.Duration.String (e.g. "1.5s");
+// JSON output emits the duration in milliseconds as a number under key+"_ms".
+func Dur(key string, val time.Duration) Field {
+	return Field{Key: key, kind: durationKind, num: int64(val)}
+}
+
+// Err returns an error field stored under the "error" key.
+// A nil error is rendered as "<nil>".
+func Err(err error) Field {
+	return Field{Key: "error", kind: errorKind, err: err}
+}
+
+// Bytes returns a byte-size field, rendered with binary units in text (e.g. "1.5MiB")
+// and as the raw number of bytes in JSON.
+func Bytes(key string, n int64) Field {
+	return Field{Key: key, kind: bytesKind, num: n}
+}
+
+// Any returns a field holding an arbitrary value, rendered as JSON. Structs and maps
+// become nested objects in JSON output and are flattened into dotted keys in text output
+// ("user.id=5 user.name=alice", up to 8 levels deep); other values are written as compact
+// JSON. Values that cannot be encoded fall back to their %+v representation, and cyclic
+// values to the encoding error.
+func Any(key string, val interface{}) Field {
+	return Field{Key: key, kind: anyKind, any: val}
+}
+
+// Value returns the field value rendered as a plain string.
+func (f Field) Value() string {
+	switch f.kind {
+	case intKind:
+		return strconv.FormatInt(f.num, 10)
+	case boolKind:
+		return strconv.FormatBool(f.num == 1)
+	case floatKind:
+		return strconv.FormatFloat(math.Float64frombits(uint64(f.num)), 'g', -1, 64)
+	case durationKind:
+		return time.Duration(f.num).String()
+	case errorKind:
+		if f.err == nil {
+			return "<nil>"
+		}
+		return f.err.Error()
+	case anyKind:
+		return string(f.appendAnyJSON(nil))
+	case bytesKind:
+		return string(appendByteSize(nil, f.num))
+	default:
+		return f.str
+	}
+}
+
+// sortedFields returns fields ordered by key, keeping the order of fields with equal keys.
+// Already sorted fields are returned as is; otherwise a sorted copy is made.
+func sortedFields(fields []Field) []Field {
+	i := 1
+	for i < len(fields) && fields[i-1].Key <= fields[i].Key {
+		i++
+	}
+	if i >= len(fields) {
+		return fields
+	}
+	out := append([]Field(nil), fields...)
+	// Insertion sort is stable and fast for the handful of fields an entry carries.
+	for ; i < len(out); i++ {
+		for j := i; j > 0 && out[j].Key < out[j-1].Key; j-- {
+			out[j], out[j-1] = out[j-1], out[j]
+		}
+	}
+	return out
+}
+
+// appendFieldsText appends fields to buf as space-separated key=value pairs, sorted by key.
+// Values containing spaces, quotes or '=' are quoted.
+func appendFieldsText(buf []byte, fields []Field) []byte {
+	for _, f := range sortedFields(fields) {
+		buf = append(buf, ' ')
+		if f.kind == anyKind {
+			buf = f.appendAnyText(buf)
+			continue
+		}
+		buf = append(buf, f.Key...)
+		buf = append(buf, '=')
+		switch f.kind {
+		case intKind:
+			buf = strconv.AppendInt(buf, f.num, 10)
+			continue
+		case boolKind:
+			buf = strconv.AppendBool(buf, f.num == 1)
+			continue
+		}
+		v := f.Value()
+		if v == "" || needsQuote(v) {
+			buf = strconv.AppendQuote(buf, v)
+		} else {
+			buf = append(buf, v...)
+		}
+	}
+	return buf
+}
+
+// needsQuote reports whether a text field value must be quoted:
+// it contains spaces, quotes, '=' or control characters.
+func needsQuote(v string) bool {
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		if c <= ' ' || c == '"' || c == '=' || c == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// appendJSONKey appends the quoted JSON key of the field, with the unit suffix of durations.
+func (f Field) appendJSONKey(buf []byte) []byte {
+	if f.kind == durationKind {
+		return appendJSONString(buf, f.Key+"_ms")
+	}
+	return appendJSONString(buf, f.Key)
+}
+
+// appendJSONValue appends the field value as a JSON value.
+func (f Field) appendJSONValue(buf []byte) []byte {
+	switch f.kind {
+	case intKind, bytesKind:
+		return strconv.AppendInt(buf, f.num, 10)
+	case boolKind:
+		return strconv.AppendBool(buf, f.num == 1)
+	case floatKind:
+		v := math.Float64frombits(uint64(f.num))
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			// Not representable as a JSON number.
+			return appendJSONString(buf, f.Value())
+		}
+		return strconv.AppendFloat(buf, v, 'g', -1, 64)
+	case durationKind:
+		return strconv.AppendFloat(buf, float64(f.num)/float64(time.Millisecond), 'f', -1, 64)
+	case errorKind:
+		if f.err == nil {
+			return append(buf, "null"...)
+		}
+		return appendJSONString(buf, f.err.Error())
+	case anyKind:
+		return f.appendAnyJSON(buf)
+	default:
+		return appendJSONString(buf, f.Value())
+	}
+}
+
+// appendAnyJSON appends the JSON encoding of an Any field value.
+func (f Field) appendAnyJSON(buf []byte) []byte {
+	if err, ok := f.any.(error); ok {
+		return appendJSONString(buf, err.Error())
+	}
+	data, err := json.Marshal(f.any)
+	if err != nil {
+		var cycle *json.UnsupportedValueError
+		if errors.As(err, &cycle) {
+			// Formatting a cyclic value with fmt would not terminate.
+			return appendJSONString(buf, err.Error())
+		}
+		return appendJSONString(buf, fmt.Sprintf("%+v", f.any))
+	}
+	return append(buf, data...)
+}
+
+// byteUnits are the binary size units used by appendByteSize.
+var byteUnits = [...]string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// appendByteSize appends n as a size with one decimal and a binary unit, e.g. "512B" or "1.5MiB".
+func appendByteSize(buf []byte, n int64) []byte {
+	v := float64(n)
+	if n < 0 {
+		v = -v
+	}
+	if v < 1024 {
+		buf = strconv.AppendInt(buf, n, 10)
+		return append(buf, 'B')
+	}
+	i := -1
+	for v >= 1024 && i < len(byteUnits)-1 {
+		v /= 1024
+		i++
+	}
+	if n < 0 {
+		v = -v
+	}
+	buf = strconv.AppendFloat(buf, v, 'f', 1, 64)
+	return append(buf, byteUnits[i]...)
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextFieldOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		parent []Field
+		fields []Field
+		want   string
+	}{
+		{"sorted", nil, []Field{Str("a", "1"), Str("b", "2")}, "msg a=1 b=2"},
+		{"unsorted", nil, []Field{Str("zone", "eu"), Int("attempt", 2), Bool("ok", true)}, "msg attempt=2 ok=true zone=eu"},
+		{"parent fields", []Field{Str("user", "alice")}, []Field{Str("path", "/")}, "msg path=/ user=alice"},
+		{"equal keys keep order", nil, []Field{Str("k", "first"), Str("a", "x"), Str("k", "second")}, "msg a=x k=first k=second"},
+		{"nested keys", nil, []Field{Any("m", map[string]int{"z": 1, "b": 2, "k": 3})}, "msg m.b=2 m.k=3 m.z=1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console := newTestLogger(t)
+			child := l.WithFields(tt.parent...)
+			// Map iteration order varies between runs; the output must not.
+			for i := 0; i < 50; i++ {
+				console.Reset()
+				child.Event(INFO, "msg", tt.fields...)
+				if got := textMessages(console.String()); len(got) != 1 || got[0] != tt.want {
+					t.Fatalf("run %d: got %q, want %q", i, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONFieldOrder(t *testing.T) {
+	l, console := newTestLogger(t)
+	l.SetConsoleFormat(FormatJSON)
+	l.WithFields(Str("zone", "eu")).Event(INFO, "msg", Int("b", 1), Int("a", 2))
+	out := console.String()
+	if !strings.Contains(out, `"zone":"eu","b":1,"a":2`) {
+		t.Errorf("JSON = %s, want fields in collection order", out)
+	}
+}
+
+func TestSortedFieldsKeepsInput(t *testing.T) {
+	fields := []Field{Str("b", "1"), Str("a", "2")}
+	sortedFields(fields)
+	if fields[0].Key != "b" {
+		t.Error("sortedFields reordered its input")
+	}
+}
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileSink is a Sink appending entries to a file, see AddFileSink.
+type fileSink struct {
+	file *os.File
+	path string
+}
+
+// AddFileSink opens (or creates) the file at path, creating directories if needed, and
+// attaches it as a sink for entries from minLevel up to and including maxLevel in the
+// current file format. Unlike the main log file, it is not affected by the file level;
+// that makes it possible to keep one file per severity band:
+//
+//	log.AddFileSink("logs/outcomes.log", logger.SUCCESS, logger.FAIL)
+//
+// The file is closed by Close. A path naming the main log file, a per-level file or
+// another file sink, also through a symbolic link, is rejected, as two handles appending
+// to one file would interleave and duplicate entries.
+func (l *Logger) AddFileSink(path string, minLevel, maxLevel LogLevel) error {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.checkFileInUse(path); err != nil {
+		return err
+	}
+	file, err := openFile(path)
+	if err != nil {
+		return err
+	}
+	sink := &fileSink{file: file, path: path}
+	l.sinks = append(l.sinks, &sinkOutput{sink: sink, level: minLevel, maxLevel: maxLevel, format: l.fileFormat})
+	return nil
+}
+
+// checkFileInUse returns an error if path names a file the logger already writes to.
+// Must be called with l.mu held.
+func (l *Logger) checkFileInUse(path string) error {
+	if l.logFile != nil && samePath(path, l.logPath) {
+		return fmt.Errorf("file sink %q is the main log file", path)
+	}
+	for _, lf := range l.levelFiles {
+		if lf.file != nil && samePath(path, lf.path) {
+			return fmt.Errorf("file sink %q is already a per-level log file", path)
+		}
+	}
+	for _, so := range l.sinks {
+		if fs, ok := so.sink.(*fileSink); ok && samePath(path, fs.path) {
+			return fmt.Errorf("file sink %q is already attached", path)
+		}
+	}
+	return nil
+}
+
+// Write appends one entry to the file.
+func (s *fileSink) Write(_ time.Time, _ LogLevel, entry []byte) error {
+	_, err := s.file.Write(entry)
+	return err
+}
+
+// Close closes the file.
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAddFileSinkInUse(t *testing.T) {
+	dir := t.TempDir()
+	main := filepath.Join(dir, "main.log")
+	errs := filepath.Join(dir, "error.log")
+	band := filepath.Join(dir, "band.log")
+	link := filepath.Join(dir, "band-link.log")
+
+	l := NewLogger(DISABLED, DEBUG)
+	defer l.Close()
+	if err := l.SetLogFile(main); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetLevelFile(ERROR, errs); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.AddFileSink(band, SUCCESS, FAIL); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(band, link); err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{"main log file", main, true},
+		{"per-level file", errs, true},
+		{"file sink", band, true},
+		{"symlink to file sink", link, true},
+		{"new file", filepath.Join(dir, "other.log"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := l.AddFileSink(tt.path, DEBUG, ERROR); (err != nil) != tt.wantErr {
+				t.Errorf("AddFileSink(%q) error = %v, want error %v", tt.path, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddFileSinkRange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "band.log")
+	l := NewLogger(DISABLED, DISABLED)
+	if err := l.AddFileSink(path, SUCCESS, FAIL); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("info")
+	l.Success("success")
+	l.Fail("fail")
+	l.Error("error")
+	l.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := textMessages(string(data)); strings.Join(got, ",") != "success,fail" {
+		t.Errorf("file sink got %q, want success and fail only", got)
+	}
+}
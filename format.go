@@ -0,0 +1,412 @@
+package logger
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Format selects how entries are rendered for an output.
+type Format int
+
+// Available output formats.
+const (
+	FormatText Format = iota // "time | LEVEL | message key=value" (default)
+	FormatJSON               // one JSON object per line
+	FormatGCP                // JSON with Google Cloud Logging keys and severities
+)
+
+// entry is a single log record, captured once and rendered per output.
+type entry struct {
+	time    time.Time
+	level   LogLevel
+	message string
+	fields  []Field
+	caller  string
+	pkg     string // calling package, see SetIncludePackage
+	name    string // logger name set with Named
+	stack   string // stack trace, see SetStackTraceLevel
+	table   *tableData
+	diff    *diffData
+	seq     uint64 // 0 when sequence numbers are disabled
+
+	// minLevel replaces the output thresholds when hasMinLevel is set, see WithLevel.
+	minLevel    LogLevel
+	hasMinLevel bool
+
+	audit   bool // written regardless of levels and synced, see Audit
+	grouped bool // released from its goroutine's group, see SetGoroutineGrouping
+
+	skipConsole bool // not written to the console, see Progress
+	banner      bool // rendered as a bordered block on a text console, see Banner
+	indent      int  // text indentation of the message in steps of two spaces, see Span
+}
+
+// Alignment selects how the level is placed in its column in text output.
+type Alignment int
+
+// Available level alignments.
+const (
+	AlignNone  Alignment = iota // level names as they are (default)
+	AlignLeft                   // "| INFO    |"
+	AlignRight                  // "|    INFO |"
+)
+
+// defaultLevelWidth fits the longest level name, "UNKNOWN".
+const defaultLevelWidth = 7
+
+// SetLevelAlignment pads the level in text output to width characters, aligned as
+// given, so messages start in the same column whatever the level. A width <= 0 fits
+// the longest level name. Longer labels (e.g. with SetNumericLevels) are not cut.
+func (l *Logger) SetLevelAlignment(align Alignment, width int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if width <= 0 {
+		width = defaultLevelWidth
+	}
+	l.levelAlign, l.levelWidth = align, width
+}
+
+// SetConsoleFormat sets the format used for console output.
+func (l *Logger) SetConsoleFormat(f Format) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.consoleFormat = f
+}
+
+// SetFileFormat sets the format used for the log file and per-level files.
+func (l *Logger) SetFileFormat(f Format) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fileFormat = f
+}
+
+// SetSchemaVersion adds a "schema" field with v to every JSON entry, so consumers can
+// tell apart entries written under different field conventions. Zero removes it.
+func (l *Logger) SetSchemaVersion(v int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.schemaVersion = v
+}
+
+// SetIndentMultiline indents the continuation lines of multi-line text entries (messages
+// with newlines, stack traces, tables, diffs) to the column where the message starts,
+// so they read as part of the entry. JSON output is unaffected. Newlines in messages are
+// kept even where control characters are escaped (see SetSanitizeControlChars): an
+// indented line cannot be mistaken for a new entry.
+func (l *Logger) SetIndentMultiline(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.indentMultiline = enabled
+}
+
+// SetLineEnding sets the line ending of the log file and per-level files, e.g. "\r\n"
+// for Windows tools that expect CRLF. It also separates the lines of multi-line entries
+// such as stack traces. An empty string restores the default "\n".
+func (l *Logger) SetLineEnding(ending string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if ending == "\n" {
+		ending = ""
+	}
+	l.lineEnding = ending
+}
+
+// formatFile appends e rendered for the log file to buf, with the configured line ending.
+// Must be called with l.mu held.
+func (l *Logger) formatFile(buf []byte, e *entry) []byte {
+	start := len(buf)
+	buf = l.format(buf, e, l.fileFormat, false)
+	if l.lineEnding == "" {
+		return buf
+	}
+	return replaceNewlines(buf, start, l.lineEnding)
+}
+
+// replaceNewlines replaces every '\n' in buf[start:] with repl, in place.
+func replaceNewlines(buf []byte, start int, repl string) []byte {
+	// Rewrite the tail after itself, then move it back in place.
+	end := len(buf)
+	for i := start; i < end; i++ {
+		if buf[i] == '\n' {
+			buf = append(buf, repl...)
+		} else {
+			buf = append(buf, buf[i])
+		}
+	}
+	n := copy(buf[start:], buf[end:])
+	return buf[:start+n]
+}
+
+// format appends e rendered as a single line in format f to buf.
+// Console output gets colors (text only) and the console sanitize setting.
+// Must be called with l.mu held.
+func (l *Logger) format(buf []byte, e *entry, f Format, console bool) []byte {
+	if f == FormatJSON || f == FormatGCP {
+		gcp := f == FormatGCP
+		if console && l.prettyJSON && l.consoleIsTerminal() {
+			compact := getBuffer()
+			*compact = l.appendJSON((*compact)[:0], e, gcp)
+			buf = appendPrettyJSON(buf, *compact, l.colorEnabled())
+			putBuffer(compact)
+		} else {
+			buf = l.appendJSON(buf, e, gcp)
+		}
+	} else if e.banner && console {
+		buf = appendBanner(buf, e, l.colorEnabled())
+	} else {
+		buf = l.appendText(buf, e, console)
+	}
+	return append(buf, '\n')
+}
+
+// appendText renders e in the text format.
+// Must be called with l.mu held.
+func (l *Logger) appendText(buf []byte, e *entry, console bool) []byte {
+	color := console && l.colorEnabled()
+	sanitize := sanitizeOff
+	if (console && l.sanitizeConsole) || (!console && l.sanitizeFile) {
+		sanitize = sanitizeEscape
+		if l.indentMultiline {
+			sanitize = sanitizeKeepNewlines
+		}
+	}
+	lineStart := len(buf)
+	if l.relativeTime {
+		buf = appendElapsed(buf, e.time.Sub(l.start))
+	} else {
+		buf = e.time.AppendFormat(buf, l.timePrecision.timeLayout())
+	}
+	code := ""
+	if color {
+		code = l.levelColor(e.level)
+	}
+	buf = append(buf, code...)
+	buf = append(buf, " | "...)
+	label := len(buf)
+	buf = append(buf, levelToString(e.level)...)
+	if l.numericLevels {
+		buf = append(buf, '(')
+		buf = strconv.AppendInt(buf, int64(l.severity(e.level)), 10)
+		buf = append(buf, ')')
+	}
+	buf = l.alignLevel(buf, label)
+	buf = append(buf, " |"...)
+	if code != "" {
+		buf = append(buf, reset...)
+	}
+	buf = append(buf, ' ')
+	if !l.indentMultiline {
+		return e.appendMessage(buf, sanitize, color)
+	}
+
+	// Indent continuation lines to the column where the message starts.
+	width := len(buf) - lineStart
+	if code != "" {
+		width -= len(code) + len(reset)
+	}
+	msgStart := len(buf)
+	buf = e.appendMessage(buf, sanitize, color)
+	return replaceNewlines(buf, msgStart, "\n"+strings.Repeat(" ", width))
+}
+
+// alignLevel pads the level label starting at buf[label:] to the configured width.
+// Must be called with l.mu held.
+func (l *Logger) alignLevel(buf []byte, label int) []byte {
+	pad := l.levelWidth - (len(buf) - label)
+	if l.levelAlign == AlignNone || pad <= 0 {
+		return buf
+	}
+	for i := 0; i < pad; i++ {
+		buf = append(buf, ' ')
+	}
+	if l.levelAlign == AlignRight {
+		copy(buf[label+pad:], buf[label:len(buf)-pad])
+		for i := label; i < label+pad; i++ {
+			buf[i] = ' '
+		}
+	}
+	return buf
+}
+
+// appendMessage appends the caller, message and fields of e in the text format.
+// Control characters in the message are written as selected by sanitize; with color,
+// Highlight spans and attached diffs are colorized.
+func (e *entry) appendMessage(buf []byte, sanitize sanitizeMode, color bool) []byte {
+	if e.seq != 0 {
+		buf = append(buf, '#')
+		buf = strconv.AppendUint(buf, e.seq, 10)
+		buf = append(buf, " | "...)
+	}
+	if e.caller != "" {
+		buf = append(buf, e.caller...)
+		buf = append(buf, " | "...)
+	}
+	if e.pkg != "" {
+		buf = append(buf, e.pkg...)
+		buf = append(buf, ": "...)
+	}
+	if e.name != "" {
+		buf = append(buf, '[')
+		buf = append(buf, e.name...)
+		buf = append(buf, "] "...)
+	}
+	for i := 0; i < e.indent; i++ {
+		buf = append(buf, "  "...)
+	}
+	buf = appendHighlighted(buf, e.message, sanitize, color)
+	buf = appendFieldsText(buf, e.fields)
+	if e.table != nil {
+		buf = e.table.appendText(buf, sanitize != sanitizeOff)
+	}
+	if e.diff != nil {
+		buf = e.diff.appendText(buf, sanitize != sanitizeOff, color)
+	}
+	if e.stack != "" {
+		buf = append(buf, '\n')
+		buf = append(buf, e.stack...)
+	}
+	return buf
+}
+
+// appendJSON renders e as a JSON object. With gcp, the time, level and trace ID use the
+// keys and severity names expected by Google Cloud Logging.
+// Must be called with l.mu held.
+func (l *Logger) appendJSON(buf []byte, e *entry, gcp bool) []byte {
+	if gcp {
+		buf = append(buf, `{"timestamp":`...)
+	} else {
+		buf = append(buf, `{"time":`...)
+	}
+	buf = appendJSONString(buf, e.time.Format(time.RFC3339Nano))
+	if l.relativeTime {
+		buf = append(buf, `,"elapsed":`...)
+		buf = strconv.AppendFloat(buf, e.time.Sub(l.start).Seconds(), 'f', 6, 64)
+	}
+	if e.seq != 0 {
+		buf = append(buf, `,"seq":`...)
+		buf = strconv.AppendUint(buf, e.seq, 10)
+	}
+	if gcp {
+		buf = append(buf, `,"severity":`...)
+		buf = appendJSONString(buf, gcpSeverity(e.level))
+	} else {
+		buf = append(buf, `,"level":`...)
+		buf = appendJSONString(buf, levelToString(e.level))
+	}
+	if l.numericLevels {
+		buf = append(buf, `,"severity_number":`...)
+		buf = strconv.AppendInt(buf, int64(l.severity(e.level)), 10)
+	}
+	if l.schemaVersion != 0 {
+		buf = append(buf, `,"schema":`...)
+		buf = strconv.AppendInt(buf, int64(l.schemaVersion), 10)
+	}
+	if e.name != "" {
+		buf = append(buf, `,"logger":`...)
+		buf = appendJSONString(buf, e.name)
+	}
+	if e.caller != "" {
+		buf = append(buf, `,"caller":`...)
+		buf = appendJSONString(buf, e.caller)
+	}
+	if e.pkg != "" {
+		buf = append(buf, `,"package":`...)
+		buf = appendJSONString(buf, e.pkg)
+	}
+	buf = append(buf, `,"message":`...)
+	buf = appendJSONString(buf, stripHighlights(e.message))
+	for _, f := range e.fields {
+		buf = append(buf, ',')
+		if gcp && f.Key == traceIDField && f.kind == stringKind {
+			buf = append(buf, `"logging.googleapis.com/trace":`...)
+			buf = appendJSONString(buf, l.gcpTrace(f.str))
+			continue
+		}
+		buf = f.appendJSONKey(buf)
+		buf = append(buf, ':')
+		buf = f.appendJSONValue(buf)
+	}
+	if e.table != nil {
+		buf = append(buf, `,"rows":`...)
+		buf = e.table.appendJSON(buf)
+		if e.table.omitted > 0 {
+			buf = append(buf, `,"omitted_rows":`...)
+			buf = strconv.AppendInt(buf, int64(e.table.omitted), 10)
+		}
+	}
+	if e.diff != nil {
+		buf = append(buf, `,"diff":`...)
+		buf = e.diff.appendJSON(buf)
+	}
+	if e.stack != "" {
+		buf = append(buf, `,"stack":`...)
+		buf = appendJSONString(buf, e.stack)
+	}
+	return append(buf, '}')
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s as a quoted JSON string.
+// Invalid UTF-8 is replaced with U+FFFD.
+func appendJSONString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				buf = append(buf, '\\', c)
+			case c == '\n':
+				buf = append(buf, '\\', 'n')
+			case c == '\r':
+				buf = append(buf, '\\', 'r')
+			case c == '\t':
+				buf = append(buf, '\\', 't')
+			case c < 0x20:
+				buf = append(buf, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			default:
+				buf = append(buf, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, `�`...)
+		} else {
+			buf = append(buf, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(buf, '"')
+}
+
+// FormatMessage returns msg rendered at level exactly as it would be written to the
+// console (format, colors, fields of l), without the trailing newline and without writing it.
+func (l *Logger) FormatMessage(level LogLevel, msg string) string {
+	return l.formatMessage(level, msg, true)
+}
+
+// FormatMessageNoColor is like FormatMessage but renders with the file settings, without colors.
+func (l *Logger) FormatMessageNoColor(level LogLevel, msg string) string {
+	return l.formatMessage(level, msg, false)
+}
+
+// formatMessage renders an entry for the console or file settings without writing it.
+func (l *Logger) formatMessage(level LogLevel, msg string, console bool) string {
+	t := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e := l.newEntry(t, level, msg, nil)
+	f := l.fileFormat
+	if console {
+		f = l.consoleFormat
+	}
+	buf := l.format(nil, &e, f, console)
+	return string(buf[:len(buf)-1])
+}
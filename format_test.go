@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// newDualLogger returns a test logger also writing the file output to the returned buffer.
+func newDualLogger(t *testing.T, consoleFormat, fileFormat Format) (l *Logger, console, file *bytes.Buffer) {
+	t.Helper()
+	l, console = newTestLogger(t)
+	file = &bytes.Buffer{}
+	l.SetFileWriter(file)
+	l.SetFileLevel(DEBUG)
+	l.SetConsoleFormat(consoleFormat)
+	l.SetFileFormat(fileFormat)
+	return l, console, file
+}
+
+// checkEntry checks that out holds a single entry in format f for the event logged by
+// the format tests.
+func checkEntry(t *testing.T, what string, f Format, out string) {
+	t.Helper()
+	switch f {
+	case FormatJSON:
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(out), &m); err != nil {
+			t.Fatalf("%s: not one JSON entry: %q", what, out)
+		}
+		want := map[string]interface{}{"level": "INFO", "message": "saved", "user": "alice", "n": float64(3)}
+		for k, v := range want {
+			if m[k] != v {
+				t.Errorf("%s: %s = %v, want %v", what, k, m[k], v)
+			}
+		}
+	default:
+		if strings.HasPrefix(out, "{") || !strings.HasSuffix(out, " | INFO | saved n=3 user=alice\n") {
+			t.Errorf("%s: got %q, want a text entry with the fields", what, out)
+		}
+	}
+}
+
+func TestConsoleAndFileFormatsIndependent(t *testing.T) {
+	tests := []struct {
+		name          string
+		console, file Format
+	}{
+		{"json console, text file", FormatJSON, FormatText},
+		{"text console, json file", FormatText, FormatJSON},
+		{"text both", FormatText, FormatText},
+		{"json both", FormatJSON, FormatJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console, file := newDualLogger(t, tt.console, tt.file)
+			l.WithFields(Str("user", "alice")).Event(INFO, "saved", Int("n", 3))
+			checkEntry(t, "console", tt.console, console.String())
+			checkEntry(t, "file", tt.file, file.String())
+		})
+	}
+}
+
+func TestIndentMultiline(t *testing.T) {
+	tests := []struct {
+		name     string
+		indent   bool
+		sanitize bool
+		message  string
+		want     string // output after the level column
+	}{
+		{"escaped without indent", false, true, "line1\nline2", "line1\\nline2\n"},
+		{"indented with sanitizing", true, true, "line1\nline2", "line1\n" + pad + "line2\n"},
+		{"indented without sanitizing", true, false, "line1\nline2", "line1\n" + pad + "line2\n"},
+		{"other controls still escaped", true, true, "a\rb\x1b[2J\nc", "a\\rb\\x1b[2J\n" + pad + "c\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console := newTestLogger(t)
+			l.SetIndentMultiline(tt.indent)
+			if !tt.sanitize {
+				l.SetSanitizeControlChars(false)
+			}
+			l.Info("%s", tt.message)
+			out := console.String()
+			if i := strings.Index(out, " | INFO | "); i < 0 || out[i+len(" | INFO | "):] != tt.want {
+				t.Errorf("got %q, want message %q", out, tt.want)
+			}
+		})
+	}
+}
+
+// pad is the indentation of continuation lines under the default time layout and level.
+var pad = strings.Repeat(" ", len("02/01/2006 15:04:05.000000 | INFO | "))
@@ -0,0 +1,27 @@
+package logger
+
+import "time"
+
+// SetGaugeHook registers fn to receive every value recorded with Gauge, e.g. to forward
+// it to a metrics system. fn is called synchronously after the entry is written. A nil fn
+// removes the hook.
+func (l *Logger) SetGaugeHook(fn func(name string, value float64)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.gaugeHook = fn
+}
+
+// Gauge logs an instantaneous measurement (queue depth, memory, ...) at INFO level as
+// "gauge metric=<name> value=<value>", with the value as a JSON number in JSON output.
+func (l *Logger) Gauge(name string, value float64) {
+	t := time.Now()
+
+	l.mu.Lock()
+	l.emit(t, INFO, "gauge", []Field{Str("metric", name), Float64("value", value)})
+	hook := l.gaugeHook
+	l.mu.Unlock()
+
+	if hook != nil {
+		hook(name, value)
+	}
+}
@@ -0,0 +1,37 @@
+package logger
+
+// SetGCPProjectID sets the Google Cloud project used by FormatGCP to expand trace IDs
+// into "projects/<id>/traces/<trace>" as Cloud Logging expects. Without it, trace IDs
+// are written as given.
+func (l *Logger) SetGCPProjectID(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.gcpProject = id
+}
+
+// gcpSeverity maps a level to a Cloud Logging severity.
+func gcpSeverity(level LogLevel) string {
+	switch level {
+	case DEBUG:
+		return "DEBUG"
+	case INFO:
+		return "INFO"
+	case SUCCESS:
+		return "NOTICE"
+	case FAIL:
+		return "WARNING"
+	case ERROR:
+		return "ERROR"
+	default:
+		return "DEFAULT"
+	}
+}
+
+// gcpTrace returns the Cloud Logging trace resource name for trace ID id.
+// Must be called with l.mu held.
+func (l *Logger) gcpTrace(id string) string {
+	if l.gcpProject == "" {
+		return id
+	}
+	return "projects/" + l.gcpProject + "/traces/" + id
+}
@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"sort"
+	"time"
+)
+
+// maxGroupEntries is the number of entries held for one goroutine before its group is
+// written regardless of SetGoroutineGrouping's idle time.
+const maxGroupEntries = 100
+
+// goroutineGroup holds consecutive entries of one goroutine, see SetGoroutineGrouping.
+type goroutineGroup struct {
+	entries []entry
+	last    time.Time // when the latest entry was held
+}
+
+// SetGoroutineGrouping holds each goroutine's entries and writes them as one uninterrupted
+// group once that goroutine has logged nothing for idle, a heuristic for it having
+// yielded or finished its piece of work. This keeps the output of concurrent workers
+// readable at the cost of delaying it by at least idle; groups are written in the order
+// they started and keep their original timestamps, so timestamps across groups are not
+// monotonic. A group reaching 100 entries is written at once; Flush and Close write all
+// held groups. Audit entries and recovered panics (see Recover) are never held; a panic
+// writes all held groups first.
+//
+// A non-positive idle restores immediate mode, the default: every entry is rendered and
+// written to all destinations under the logger's lock before the logging call returns,
+// so the output follows the order in which calls were made, across goroutines. An entry
+// logged after another call has returned is never written before it. A non-blocking
+// console (SetConsoleNonBlocking) keeps this order but may write later.
+func (l *Logger) SetGoroutineGrouping(idle time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.setGroupIdle(idle)
+}
+
+// setGroupIdle starts or stops goroutine grouping, writing held groups when it stops.
+// Must be called with l.mu held.
+func (l *Logger) setGroupIdle(d time.Duration) {
+	if d <= 0 {
+		d = 0
+	}
+	if d == l.groupIdle {
+		return
+	}
+	if l.groupStop != nil {
+		close(l.groupStop)
+		l.groupStop = nil
+	}
+	l.groupIdle = d
+	if d == 0 {
+		l.writeGroups(time.Time{})
+		l.groups = nil
+		return
+	}
+	l.groupStop = make(chan struct{})
+	go l.groupLoop(d, l.groupStop)
+}
+
+// holdGroupEntry adds e to the group of the calling goroutine.
+// Must be called with l.mu held.
+func (l *Logger) holdGroupEntry(e *entry) {
+	if l.groups == nil {
+		l.groups = make(map[uint64]*goroutineGroup)
+	}
+	id := goroutineID()
+	g := l.groups[id]
+	if g == nil {
+		g = &goroutineGroup{}
+		l.groups[id] = g
+	}
+	g.entries = append(g.entries, *e)
+	g.last = time.Now()
+	if len(g.entries) >= maxGroupEntries {
+		delete(l.groups, id)
+		l.writeGroup(g)
+	}
+}
+
+// writeGroups writes the groups idle since before cutoff, oldest group first.
+// A zero cutoff writes all groups.
+// Must be called with l.mu held.
+func (l *Logger) writeGroups(cutoff time.Time) {
+	var ready []*goroutineGroup
+	for id, g := range l.groups {
+		if cutoff.IsZero() || g.last.Before(cutoff) {
+			ready = append(ready, g)
+			delete(l.groups, id)
+		}
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i].entries[0].time.Before(ready[j].entries[0].time)
+	})
+	for _, g := range ready {
+		l.writeGroup(g)
+	}
+}
+
+// writeGroup writes the entries of g.
+// Must be called with l.mu held.
+func (l *Logger) writeGroup(g *goroutineGroup) {
+	for i := range g.entries {
+		e := &g.entries[i]
+		e.grouped = true
+		l.writeEntry(e)
+	}
+}
+
+// groupLoop writes idle groups until stop is closed.
+func (l *Logger) groupLoop(idle time.Duration, stop chan struct{}) {
+	interval := idle / 2
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case t := <-ticker.C:
+			l.mu.Lock()
+			if l.groupStop == stop {
+				l.writeGroups(t.Add(-idle))
+			}
+			l.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// logAlternating logs steps entries from each of two goroutines, strictly alternating
+// between them ("a0", "b0", "a1", ...).
+func logAlternating(l *Logger, steps int) {
+	turnA, turnB, done := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	worker := func(name string, mine, next chan struct{}) {
+		for i := 0; i < steps; i++ {
+			<-mine
+			l.Info(name + strconv.Itoa(i))
+			if name == "b" && i == steps-1 {
+				close(done)
+				return
+			}
+			next <- struct{}{}
+		}
+	}
+	go worker("a", turnA, turnB)
+	go worker("b", turnB, turnA)
+	turnA <- struct{}{}
+	<-done
+}
+
+func TestGoroutineGrouping(t *testing.T) {
+	tests := []struct {
+		name string
+		idle time.Duration
+		want []string
+	}{
+		{"immediate", 0, []string{"a0", "b0", "a1", "b1", "a2", "b2"}},
+		{"grouped", time.Hour, []string{"a0", "a1", "a2", "b0", "b1", "b2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console := newTestLogger(t)
+			l.SetGoroutineGrouping(tt.idle)
+			logAlternating(l, 3)
+			if tt.idle > 0 && console.Len() != 0 {
+				t.Fatalf("grouped entries written before Flush: %q", console.String())
+			}
+			_ = l.Flush()
+			if got := textMessages(console.String()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoroutineGroupingIdle(t *testing.T) {
+	l, console := newTestLogger(t)
+	l.SetGoroutineGrouping(10 * time.Millisecond)
+	l.Info("held")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		l.mu.Lock()
+		out := console.String()
+		l.mu.Unlock()
+		if strings.Contains(out, "held") {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("idle group was not written")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestGoroutineGroupingFull(t *testing.T) {
+	l, console := newTestLogger(t)
+	l.SetGoroutineGrouping(time.Hour)
+	for i := 0; i < maxGroupEntries; i++ {
+		l.Info("entry")
+	}
+	if got := len(textMessages(console.String())); got != maxGroupEntries {
+		t.Errorf("full group wrote %d entries, want %d", got, maxGroupEntries)
+	}
+}
+
+func TestGoroutineGroupingPanic(t *testing.T) {
+	l, console := newTestLogger(t)
+	l.SetGoroutineGrouping(time.Hour)
+	func() {
+		defer l.RecoverAndContinue()
+		l.Info("before")
+		panic("boom")
+	}()
+	got := textMessages(console.String())
+	if len(got) < 2 || got[0] != "before" || got[1] != "panic: boom" {
+		t.Errorf("messages = %q, want the held entry followed by the panic", got)
+	}
+}
+
+func TestGoroutineGroupingCrashLog(t *testing.T) {
+	l, console := newTestLogger(t)
+	path := filepath.Join(t.TempDir(), "crash.log")
+	l.SetCrashLog(path, 0)
+	l.SetRepanic(true)
+	l.SetGoroutineGrouping(time.Hour)
+	func() {
+		defer func() { _ = recover() }()
+		defer l.Recover()
+		l.Info("before")
+		panic("boom")
+	}()
+
+	if !strings.Contains(console.String(), "before") {
+		t.Errorf("console = %q, want the held entry", console.String())
+	}
+	crash, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(crash), "| INFO | before") || !strings.Contains(string(crash), "panic: boom") {
+		t.Errorf("crash log = %q, want the held entry and the panic", crash)
+	}
+}
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// newTestLogger returns a logger writing uncolored text to the returned console buffer
+// and nothing to files. It is closed when the test ends.
+func newTestLogger(t *testing.T) (*Logger, *bytes.Buffer) {
+	t.Helper()
+	var console bytes.Buffer
+	l := NewLogger(DEBUG, DISABLED)
+	l.SetConsoleWriter(&console)
+	l.SetColorEnabled(false)
+	t.Cleanup(l.Close)
+	return l, &console
+}
+
+// textMessages returns the message of each text entry in out, without time and level.
+func textMessages(out string) []string {
+	var msgs []string
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, " | ", 3)
+		msgs = append(msgs, parts[len(parts)-1])
+	}
+	return msgs
+}
@@ -0,0 +1,67 @@
+package logger
+
+import "strings"
+
+// Markers delimiting a Highlight span in a message. They come from the Unicode private
+// use area, so they do not occur in ordinary text and cannot be confused with escape
+// sequences coming from the logged data.
+const (
+	hlStart = "\uE000" // followed by the color code
+	hlText  = "\uE001" // followed by the highlighted text
+	hlEnd   = "\uE002"
+)
+
+// Highlight marks text to be shown in colorCode (e.g. "\033[31m" or SGR(SGRBold)),
+// for use as a format argument:
+//
+//	log.Info("request finished with %s", logger.Highlight("500", "\033[31m"))
+//
+// The logger renders the span in color on the console and writes the plain text to
+// uncolored outputs (files, JSON, sinks). The returned string carries private markers,
+// so it is only meant to be passed to this package's logging methods.
+func Highlight(text, colorCode string) string {
+	return hlStart + colorCode + hlText + text + hlEnd
+}
+
+// appendHighlighted appends the message s, rendering Highlight spans in color when color
+// is set and as their plain text otherwise. Control characters in the text are written
+// as selected by sanitize; the color codes are written as they are.
+func appendHighlighted(buf []byte, s string, sanitize sanitizeMode, color bool) []byte {
+	text := func(buf []byte, s string) []byte {
+		if sanitize != sanitizeOff {
+			return appendSanitized(buf, s, sanitize == sanitizeKeepNewlines)
+		}
+		return append(buf, s...)
+	}
+	for {
+		i := strings.Index(s, hlStart)
+		if i < 0 {
+			return text(buf, s)
+		}
+		buf = text(buf, s[:i])
+		s = s[i+len(hlStart):]
+		j := strings.Index(s, hlText)
+		k := strings.Index(s, hlEnd)
+		if j < 0 || k < j {
+			// Not produced by Highlight; keep the rest as it is.
+			return text(buf, s)
+		}
+		code, span := s[:j], s[j+len(hlText):k]
+		s = s[k+len(hlEnd):]
+		if color && code != "" {
+			buf = append(buf, code...)
+			buf = text(buf, span)
+			buf = append(buf, reset...)
+		} else {
+			buf = text(buf, span)
+		}
+	}
+}
+
+// stripHighlights returns s with Highlight spans replaced by their plain text.
+func stripHighlights(s string) string {
+	if !strings.Contains(s, hlStart) {
+		return s
+	}
+	return string(appendHighlighted(nil, s, sanitizeOff, false))
+}
@@ -0,0 +1,138 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SetHTTPBodyLogging enables logging of up to maxBytes of request bodies in LogHTTPRequest.
+// The body remains fully readable by the handler. A maxBytes <= 0 disables body logging (default).
+func (l *Logger) SetHTTPBodyLogging(maxBytes int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.httpBodyLimit = maxBytes
+}
+
+// LogHTTPRequest logs r at INFO level with its method, path, remote address and headers
+// as fields. Sensitive headers are redacted (see SetRedactKeys).
+func (l *Logger) LogHTTPRequest(r *http.Request) {
+	l.mu.Lock()
+	limit := l.httpBodyLimit
+	fields := []Field{
+		Str("method", r.Method),
+		Str("path", r.URL.Path),
+		Str("remote", r.RemoteAddr),
+	}
+	if r.URL.RawQuery != "" {
+		fields = append(fields, Str("query", r.URL.RawQuery))
+	}
+	fields = l.appendHeaderFields(fields, r.Header)
+	l.mu.Unlock()
+
+	if limit > 0 && r.Body != nil && r.Body != http.NoBody {
+		body, err := io.ReadAll(io.LimitReader(r.Body, int64(limit)))
+		// Put the consumed prefix back in front of the remaining body.
+		r.Body = readCloser{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+		if err == nil {
+			fields = append(fields, Str("body", string(body)))
+		}
+	}
+
+	l.output(INFO, "http request", fields)
+}
+
+// LogHTTPResponse logs the response to r with its status, duration and (optional) headers.
+// Statuses >= 500 are logged at ERROR, >= 400 at FAIL and everything else at INFO.
+func (l *Logger) LogHTTPResponse(r *http.Request, status int, header http.Header, d time.Duration) {
+	level := INFO
+	switch {
+	case status >= 500:
+		level = ERROR
+	case status >= 400:
+		level = FAIL
+	}
+
+	l.mu.Lock()
+	fields := []Field{
+		Str("method", r.Method),
+		Str("path", r.URL.Path),
+		Int("status", status),
+		Dur("duration", d),
+	}
+	fields = l.appendHeaderFields(fields, header)
+	l.mu.Unlock()
+
+	l.output(level, "http response", fields)
+}
+
+// appendHeaderFields appends one "header.<Name>" field per header, sorted by name.
+// Must be called with l.mu held.
+func (l *Logger) appendHeaderFields(fields []Field, header http.Header) []Field {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if l.isRedacted(name) {
+			value = redactedValue
+		}
+		fields = append(fields, Str("header."+name, value))
+	}
+	return fields
+}
+
+// SetHTTPPanicDetails configures whether RecoverMiddleware includes the panic value in
+// the 500 response body (useful in development). By default the body is the generic
+// "Internal Server Error" text so that internals are not exposed.
+func (l *Logger) SetHTTPPanicDetails(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.httpPanicDetails = enabled
+}
+
+// RecoverMiddleware wraps next so that a panicking handler is logged at FAIL level with
+// the request method, path and remote address and the stack trace, and the client gets
+// a 500 response. http.ErrAbortHandler is re-panicked so the server aborts the response
+// as usual.
+func (l *Logger) RecoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+			l.logPanic(v, []Field{
+				Str("method", r.Method),
+				Str("path", r.URL.Path),
+				Str("remote", r.RemoteAddr),
+			})
+
+			l.mu.Lock()
+			details := l.httpPanicDetails
+			l.mu.Unlock()
+			body := http.StatusText(http.StatusInternalServerError)
+			if details {
+				body = fmt.Sprintf("panic: %v", v)
+			}
+			http.Error(w, body, http.StatusInternalServerError)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readCloser combines a reader with the closer of the original body.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
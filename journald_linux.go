@@ -0,0 +1,36 @@
+//go:build linux
+
+package logger
+
+import (
+	"fmt"
+	"net"
+)
+
+// journalSocket is the path of the journald native protocol socket.
+const journalSocket = "/run/systemd/journal/socket"
+
+// journal is a connection to the local journald.
+type journal struct {
+	conn *net.UnixConn
+}
+
+// openJournal connects to the journald socket.
+func openJournal() (*journal, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journalSocket, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to journald: %w", err)
+	}
+	return &journal{conn: conn}, nil
+}
+
+// send writes one encoded entry as a single datagram.
+func (j *journal) send(p []byte) error {
+	_, err := j.conn.Write(p)
+	return err
+}
+
+// close closes the connection.
+func (j *journal) close() error {
+	return j.conn.Close()
+}
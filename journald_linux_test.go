@@ -0,0 +1,57 @@
+//go:build linux
+
+package logger
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournaldFileLevel(t *testing.T) {
+	addr := &net.UnixAddr{Name: filepath.Join(t.TempDir(), "journal.sock"), Net: "unixgram"}
+	server, err := net.ListenUnixgram("unixgram", addr)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer server.Close()
+	conn, err := net.DialUnix("unixgram", nil, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLogger(DISABLED, ERROR)
+	l.SetConsoleWriter(io.Discard)
+	if err := l.SetLogFile(filepath.Join(t.TempDir(), "app.log")); err != nil {
+		t.Fatal(err)
+	}
+	l.mu.Lock()
+	l.journal = &journal{conn: conn}
+	l.mu.Unlock()
+	l.Debug("debug entry")
+	l.Info("info entry")
+	l.Audit("audit entry")
+	l.Error("error entry")
+	l.Close()
+
+	var got []string
+	buf := make([]byte, 4096)
+	_ = server.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	for {
+		n, err := server.Read(buf)
+		if err != nil {
+			break
+		}
+		for _, line := range strings.Split(string(buf[:n]), "\n") {
+			if msg, ok := strings.CutPrefix(line, "MESSAGE="); ok {
+				got = append(got, msg)
+			}
+		}
+	}
+	if strings.Join(got, ",") != "audit entry,error entry" {
+		t.Errorf("journal got %q, want the audit and error entries", got)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+
+package logger
+
+import "errors"
+
+// journal is unavailable outside Linux.
+type journal struct{}
+
+// openJournal always fails outside Linux.
+func openJournal() (*journal, error) {
+	return nil, errors.New("journald is not supported on this platform")
+}
+
+func (j *journal) send(p []byte) error { return nil }
+
+func (j *journal) close() error { return nil }
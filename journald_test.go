@@ -0,0 +1,33 @@
+package logger
+
+import "testing"
+
+func TestSeverityMappings(t *testing.T) {
+	tests := []struct {
+		level    LogLevel
+		priority int
+		gcp      string
+		event    uint16
+		reported bool
+	}{
+		{DEBUG, 7, "DEBUG", 0, false},
+		{INFO, 6, "INFO", eventTypeInformation, true},
+		{SUCCESS, 5, "NOTICE", eventTypeInformation, true},
+		{FAIL, 4, "WARNING", eventTypeWarning, true},
+		{ERROR, 3, "ERROR", eventTypeError, true},
+	}
+	for _, tt := range tests {
+		if got := journalPriority(tt.level); got != tt.priority {
+			t.Errorf("journalPriority(%v) = %d, want %d", tt.level, got, tt.priority)
+		}
+		if got := gcpSeverity(tt.level); got != tt.gcp {
+			t.Errorf("gcpSeverity(%v) = %q, want %q", tt.level, got, tt.gcp)
+		}
+		if got, ok := eventType(tt.level); got != tt.event || ok != tt.reported {
+			t.Errorf("eventType(%v) = %#x, %v, want %#x, %v", tt.level, got, ok, tt.event, tt.reported)
+		}
+		if got, ok := levelFromString(tt.gcp); !ok || got != tt.level {
+			t.Errorf("levelFromString(%q) = %v, %v, want %v", tt.gcp, got, ok, tt.level)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SetConsoleLevel sets the minimum level written to the console; DISABLED turns
+// console output off.
+func (l *Logger) SetConsoleLevel(level LogLevel) {
+	l.setLevels(func() { l.consoleLevel = level })
+}
+
+// SetFileLevel sets the minimum level written to the log file; DISABLED turns file
+// output off.
+func (l *Logger) SetFileLevel(level LogLevel) {
+	l.setLevels(func() { l.fileLevel = level })
+}
+
+// SetLevel sets the minimum level of both the console and the log file.
+func (l *Logger) SetLevel(level LogLevel) {
+	l.setLevels(func() { l.consoleLevel, l.fileLevel = level, level })
+}
+
+// OnLevelChange registers fn to be called with the new console and file levels whenever
+// SetConsoleLevel, SetFileLevel or SetLevel changes one of them, e.g. to log the change
+// or reconfigure a dependent component. Callbacks run after the change is applied, in
+// order of registration, on the goroutine that made the change and without the logger
+// locked, so they may log and change levels themselves.
+func (l *Logger) OnLevelChange(fn func(console, file LogLevel)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levelHooks = append(l.levelHooks, fn)
+}
+
+// setLevels applies set with the logger locked and runs the OnLevelChange callbacks if
+// the console or file level changed.
+func (l *Logger) setLevels(set func()) {
+	l.mu.Lock()
+	prevConsole, prevFile := l.consoleLevel, l.fileLevel
+	set()
+	console, file := l.consoleLevel, l.fileLevel
+	hooks := l.levelHooks
+	l.mu.Unlock()
+
+	if console == prevConsole && file == prevFile {
+		return
+	}
+	for _, fn := range hooks {
+		fn(console, file)
+	}
+}
+
+// ParseLevel returns the level named s, case-insensitively: DEBUG, INFO, SUCCESS, FAIL,
+// ERROR or DISABLED. WARN and the Google Cloud Logging severities written by FormatGCP
+// are accepted as well, WARN and WARNING meaning FAIL.
+func ParseLevel(s string) (LogLevel, error) {
+	if strings.EqualFold(s, "DISABLED") {
+		return DISABLED, nil
+	}
+	if level, ok := levelFromString(s); ok {
+		return level, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q", s)
+}
+
+// String returns the name of level as written in log lines, e.g. "DEBUG", "DISABLED" for
+// DISABLED and "UNKNOWN" for values that are not a level.
+func (level LogLevel) String() string {
+	if level == DISABLED {
+		return "DISABLED"
+	}
+	return levelToString(level)
+}
+
+// MarshalText implements encoding.TextMarshaler, so levels are written by name in JSON,
+// YAML and other config formats.
+func (level LogLevel) MarshalText() ([]byte, error) {
+	if level < DEBUG || level > DISABLED {
+		return nil, fmt.Errorf("unknown log level %d", int(level))
+	}
+	return []byte(level.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseLevel.
+func (level *LogLevel) UnmarshalText(text []byte) error {
+	l, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*level = l
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the level as a string such as "DEBUG".
+func (level LogLevel) MarshalJSON() ([]byte, error) {
+	text, err := level.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a level name (see ParseLevel)
+// and, for configs written before levels were named, the level's integer value.
+func (level *LogLevel) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] != '"' {
+		n, err := strconv.Atoi(string(data))
+		if err != nil || n < int(DEBUG) || n > int(DISABLED) {
+			return fmt.Errorf("invalid log level %s", data)
+		}
+		*level = LogLevel(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return level.UnmarshalText([]byte(s))
+}
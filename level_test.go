@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLevelNames(t *testing.T) {
+	for level := DEBUG; level <= DISABLED; level++ {
+		text, err := level.MarshalText()
+		if err != nil {
+			t.Fatalf("%d: %v", level, err)
+		}
+		if string(text) != level.String() {
+			t.Errorf("MarshalText(%d) = %q, String = %q", level, text, level.String())
+		}
+		parsed, err := ParseLevel(level.String())
+		if err != nil || parsed != level {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %d", level.String(), parsed, err, level)
+		}
+	}
+	if _, err := LogLevel(42).MarshalText(); err == nil {
+		t.Error("MarshalText accepted an unknown level")
+	}
+}
+
+func TestLevelJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    LogLevel
+		wantErr bool
+	}{
+		{`"debug"`, DEBUG, false},
+		{`"Info"`, INFO, false},
+		{`"WARN"`, FAIL, false},
+		{`"disabled"`, DISABLED, false},
+		{`4`, ERROR, false},
+		{`"verbose"`, 0, true},
+		{`9`, 0, true},
+		{`1.5`, 0, true},
+	}
+	for _, tt := range tests {
+		var cfg struct{ Level LogLevel }
+		err := json.Unmarshal([]byte(`{"Level":`+tt.in+`}`), &cfg)
+		if (err != nil) != tt.wantErr || (err == nil && cfg.Level != tt.want) {
+			t.Errorf("Unmarshal(%s) = %v, %v; want %v, error %v", tt.in, cfg.Level, err, tt.want, tt.wantErr)
+		}
+	}
+
+	data, err := json.Marshal(map[LogLevel]LogLevel{FAIL: SUCCESS})
+	if err != nil || string(data) != `{"FAIL":"SUCCESS"}` {
+		t.Errorf("Marshal = %s, %v", data, err)
+	}
+}
@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Templates for SetLevelFileTemplate. Both start with the severity number, so a
+// directory listing sorts the files by severity.
+const (
+	DefaultLevelFileTemplate = "{severity}-{level}.log"        // e.g. "4-error.log"
+	DailyLevelFileTemplate   = "{severity}-{level}-{date}.log" // e.g. "4-error-2025-07-28.log"
+)
+
+// levelFile is an additional destination receiving messages of a single level.
+type levelFile struct {
+	file     *os.File // nil until the first entry for a templated file
+	path     string
+	template string // absolute path template, see SetLevelFileTemplate
+}
+
+// SetLevelFile mirrors messages of the given level into the file at path,
+// in addition to the regular console and file output. Levels configured with
+// the same path share one file. An empty path removes the destination.
+func (l *Logger) SetLevelFile(level LogLevel, path string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if path == "" {
+		l.removeLevelFile(level)
+		return nil
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if l.logFile != nil && samePath(abs, l.logPath) {
+		// Use the main file's path so writeEntry recognizes it.
+		abs = l.logPath
+		l.emit(time.Now(), INFO, fmt.Sprintf("level file %q is the main log file; entries are written once", path), nil)
+	}
+
+	// Reuse a file already opened for another level.
+	for _, lf := range l.levelFiles {
+		if lf.template == "" && samePath(lf.path, abs) {
+			l.removeLevelFile(level)
+			l.levelFiles[level] = lf
+			return nil
+		}
+	}
+
+	file, err := openFile(abs)
+	if err != nil {
+		return err
+	}
+
+	l.removeLevelFile(level)
+	if l.levelFiles == nil {
+		l.levelFiles = make(map[LogLevel]*levelFile)
+	}
+	l.levelFiles[level] = &levelFile{file: file, path: abs}
+	return nil
+}
+
+// SetLevelFileTemplate mirrors messages of each of levels (all levels if none are given)
+// into its own file in dir, named by expanding template: {severity} is the level's
+// severity number (see SetSeverity), {level} its lowercase name and {date} the entry's
+// local date as YYYY-MM-DD. With {date}, a new file is started when the date changes.
+// An empty template selects DefaultLevelFileTemplate. Files are created on the first
+// entry of their level, so levels never logged leave no empty files behind.
+func (l *Logger) SetLevelFileTemplate(dir, template string, levels ...LogLevel) error {
+	if template == "" {
+		template = DefaultLevelFileTemplate
+	}
+	abs, err := filepath.Abs(filepath.Join(dir, template))
+	if err != nil {
+		return err
+	}
+	if len(levels) == 0 {
+		levels = []LogLevel{DEBUG, INFO, SUCCESS, FAIL, ERROR}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.levelFiles == nil {
+		l.levelFiles = make(map[LogLevel]*levelFile)
+	}
+	for _, level := range levels {
+		l.removeLevelFile(level)
+		l.levelFiles[level] = &levelFile{template: abs}
+	}
+	return nil
+}
+
+// expandLevelTemplate returns the path of the templated file for level at t.
+// Must be called with l.mu held.
+func (l *Logger) expandLevelTemplate(template string, level LogLevel, t time.Time) string {
+	return strings.NewReplacer(
+		"{severity}", strconv.Itoa(l.severity(level)),
+		"{level}", strings.ToLower(levelToString(level)),
+		"{date}", t.Format("2006-01-02"),
+	).Replace(template)
+}
+
+// rollLevelFile opens the templated file lf for an entry of level at t, switching
+// to a new file when the expanded path changes. If opening fails, the previous file,
+// if any, keeps receiving entries.
+// Must be called with l.mu held.
+func (l *Logger) rollLevelFile(lf *levelFile, level LogLevel, t time.Time) {
+	if lf.file != nil && !strings.Contains(lf.template, "{date}") {
+		return
+	}
+	path := l.expandLevelTemplate(lf.template, level, t)
+	if lf.file != nil && path == lf.path {
+		return
+	}
+	file, err := openFile(path)
+	if err != nil {
+		return
+	}
+	lf.close()
+	lf.file, lf.path = file, path
+}
+
+// close closes the file of lf, if open.
+func (lf *levelFile) close() {
+	if lf.file != nil {
+		lf.file.Close()
+	}
+}
+
+// removeLevelFile detaches the destination for level, closing its file if no other level uses it.
+// Must be called with l.mu held.
+func (l *Logger) removeLevelFile(level LogLevel) {
+	lf := l.levelFiles[level]
+	if lf == nil {
+		return
+	}
+	delete(l.levelFiles, level)
+	for _, other := range l.levelFiles {
+		if other == lf {
+			return
+		}
+	}
+	lf.close()
+}
+
+// closeLevelFiles closes all per-level files.
+// Must be called with l.mu held.
+func (l *Logger) closeLevelFiles() {
+	closed := make(map[*levelFile]bool)
+	for level, lf := range l.levelFiles {
+		if !closed[lf] {
+			lf.close()
+			closed[lf] = true
+		}
+		delete(l.levelFiles, level)
+	}
+}
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"unicode/utf8"
+)
+
+// maxLineWriterLine is the longest partial line a LevelWriter buffers; longer input
+// without a newline is logged in pieces of this size.
+const maxLineWriterLine = 64 << 10
+
+// lineWriter logs each complete line written to it at a fixed level.
+// Partial lines are buffered until a newline arrives, the buffer is full or the writer is closed.
+type lineWriter struct {
+	l     *Logger
+	level LogLevel
+	mu    sync.Mutex
+	buf   []byte
+}
+
+// LevelWriter returns an io.WriteCloser that logs every line written to it at level,
+// e.g. for capturing subprocess output:
+//
+//	cmd.Stdout = log.LevelWriter(logger.INFO)
+//	cmd.Stderr = log.LevelWriter(logger.ERROR)
+//
+// Lines split across writes are reassembled; Close logs any trailing partial line. Lines
+// longer than 64 KiB, e.g. binary output, are logged in 64 KiB pieces, cut at UTF-8
+// character boundaries.
+func (l *Logger) LevelWriter(level LogLevel) io.WriteCloser {
+	return &lineWriter{l: l, level: level}
+}
+
+// Write implements io.Writer.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.l.Log(w.level, "%s", bytes.TrimSuffix(w.buf[:i], []byte("\r")))
+		w.buf = w.buf[i+1:]
+	}
+	for len(w.buf) > maxLineWriterLine {
+		n := maxLineWriterLine
+		for i := 1; i < utf8.UTFMax && !utf8.RuneStart(w.buf[n]); i++ {
+			n--
+		}
+		if !utf8.RuneStart(w.buf[n]) {
+			n = maxLineWriterLine // not UTF-8
+		}
+		w.l.Log(w.level, "%s", w.buf[:n])
+		w.buf = w.buf[n:]
+	}
+	// Compact so the buffer does not grow without bound.
+	if len(w.buf) == 0 {
+		w.buf = nil
+	}
+	return len(p), nil
+}
+
+// Close logs any buffered partial line.
+func (w *lineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.l.Log(w.level, "%s", w.buf)
+		w.buf = nil
+	}
+	return nil
+}
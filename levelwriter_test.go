@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLevelWriterLines(t *testing.T) {
+	l, console := newTestLogger(t)
+	w := l.LevelWriter(INFO)
+	for _, p := range []string{"one\ntw", "o\r\n", "three"} {
+		if _, err := w.Write([]byte(p)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := textMessages(console.String()); strings.Join(got, ",") != "one,two,three" {
+		t.Errorf("got %q, want [one two three]", got)
+	}
+}
+
+func TestLevelWriterLongLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		chunk string
+	}{
+		{"ascii", "x"},
+		{"multibyte", "é€"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console := newTestLogger(t)
+			w := l.LevelWriter(INFO).(*lineWriter)
+			long := []byte(strings.Repeat(tt.chunk, 3*maxLineWriterLine/len(tt.chunk)))
+			for len(long) > 0 {
+				n := 1000
+				if n > len(long) {
+					n = len(long)
+				}
+				_, _ = w.Write(long[:n])
+				long = long[n:]
+				if len(w.buf) > maxLineWriterLine {
+					t.Fatalf("buffered %d bytes, want at most %d", len(w.buf), maxLineWriterLine)
+				}
+			}
+			_ = w.Close()
+
+			msgs := textMessages(console.String())
+			if len(msgs) < 3 {
+				t.Fatalf("got %d entries, want the line logged in pieces", len(msgs))
+			}
+			total := 0
+			for _, m := range msgs {
+				if !utf8.ValidString(m) {
+					t.Errorf("piece cut inside a character: ...%q", m[len(m)-4:])
+				}
+				if len(m) > maxLineWriterLine {
+					t.Errorf("piece of %d bytes", len(m))
+				}
+				total += len(m)
+			}
+			if total != 3*maxLineWriterLine/len(tt.chunk)*len(tt.chunk) {
+				t.Errorf("logged %d bytes in total", total)
+			}
+		})
+	}
+}
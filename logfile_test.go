@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetLogFileDuplicate(t *testing.T) {
+	tests := []struct {
+		name  string
+		alias func(t *testing.T, dir, path string) string
+	}{
+		{"same path", func(t *testing.T, dir, path string) string { return path }},
+		{"relative path", func(t *testing.T, dir, path string) string {
+			wd, _ := os.Getwd()
+			rel, err := filepath.Rel(wd, path)
+			if err != nil {
+				t.Skip(err)
+			}
+			return rel
+		}},
+		{"symlink", func(t *testing.T, dir, path string) string {
+			link := filepath.Join(dir, "alias.log")
+			if err := os.Symlink(path, link); err != nil {
+				t.Skip(err)
+			}
+			return link
+		}},
+		{"symlinked directory", func(t *testing.T, dir, path string) string {
+			link := filepath.Join(dir, "linkdir")
+			if err := os.Symlink(filepath.Dir(path), link); err != nil {
+				t.Skip(err)
+			}
+			return filepath.Join(link, filepath.Base(path))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "logs", "app.log")
+			l := NewLogger(DISABLED, DEBUG)
+			if err := l.SetLogFile(path); err != nil {
+				t.Fatal(err)
+			}
+			if err := l.SetLogFile(tt.alias(t, dir, path)); err != nil {
+				t.Fatal(err)
+			}
+			l.Info("entry")
+			l.Close()
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := string(data)
+			if n := strings.Count(out, "| INFO | entry"); n != 1 {
+				t.Errorf("entry written %d times: %q", n, out)
+			}
+			if !strings.Contains(out, "| INFO | log file") || strings.Contains(out, "| FAIL |") {
+				t.Errorf("want an INFO duplicate notice: %q", out)
+			}
+		})
+	}
+}
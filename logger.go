@@ -1,12 +1,14 @@
 package logger
 
 import (
+	"bufio"
 	"fmt"
-	"log"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -30,108 +32,656 @@ const (
 	green  = "\033[32m"
 	yellow = "\033[33m"
 	blue   = "\033[34m"
+	purple = "\033[35m"
+
+	boldRed = "\033[1;31m"
+	dimGray = "\033[2;90m"
 )
 
 // Logger provides leveled and colorized logging with optional file output.
+// Child loggers created with WithFields share the configuration and outputs of their parent.
 type Logger struct {
-	consoleLevel LogLevel
-	fileLevel    LogLevel
-	console      *log.Logger
-	file         *log.Logger
-	logFile      *os.File
-	mu           sync.Mutex
+	*core
+	fields []Field
+	name   string
+}
+
+// core holds the configuration and outputs shared by a logger and its children.
+type core struct {
+	consoleLevel     LogLevel
+	fileLevel        LogLevel
+	levelSchedule    func(time.Time) LogLevel
+	scheduleLevel    LogLevel  // cached result of levelSchedule
+	scheduleNext     time.Time // when scheduleLevel expires
+	console          io.Writer
+	consoleAsync     *asyncWriter
+	stderr           io.Writer // destination of entries at stderrLevel and above
+	stderrLevel      LogLevel
+	orderedStreams   bool
+	fallbackStderr   bool
+	file             io.Writer
+	logFile          *os.File
+	logPath          string
+	fileMode         LogFileMode
+	defaultDir       string
+	defaultFileName  string
+	checksumOnRotate bool
+	bg               sync.WaitGroup // background work waited for by Close
+	fileBuf          *bufio.Writer
+	fileBufTarget    io.Writer
+	flushEveryN      int
+	pendingWrites    int
+	durability       DurabilityPolicy
+	syncStop         chan struct{}
+	reopenOnMissing  bool
+	lastReopenCheck  time.Time
+	levelFiles       map[LogLevel]*levelFile
+	sinks            []*sinkOutput
+	closeHooks       []func()
+	levelHooks       []func(console, file LogLevel)
+	startup          *startupBuffer
+	paused           bool
+	pauseBuf         *startupBuffer // entries held while paused, see SetPauseBuffer
+	pauseSize        int
+	crash            *crashLog
+	eventCounts      map[string]uint64 // CountEvent counters since the last report
+	eventInterval    time.Duration     // 0 for the default, negative when disabled
+	eventStop        chan struct{}
+	eventSince       time.Time
+	memStatsInterval time.Duration
+	memStatsStop     chan struct{}
+	groupIdle        time.Duration // see SetGoroutineGrouping
+	groups           map[uint64]*goroutineGroup
+	groupStop        chan struct{}
+	progress         map[string]int // last milestone per Progress label
+	progressActive   bool           // a progress bar is drawn on the console line
+	eventLog         *eventLog
+	journal          *journal
+	socket           *socketOutput
+	netOutput        *socketOutput
+	callerMode       CallerMode
+	includePackage   bool
+	stackLevel       LogLevel
+	includeSequence  bool
+	seq              atomic.Uint64
+	timePrecision    TimePrecision
+	relativeTime     bool
+	monotonic        bool
+	lastTime         time.Time // latest monotonic timestamp
+	sanitizeConsole  bool
+	sanitizeFile     bool
+	start            time.Time
+	consoleFormat    Format
+	prettyJSON       bool
+	schemaVersion    int
+	gcpProject       string
+	fileFormat       Format
+	lineEnding       string // file line ending, "" for "\n"
+	sampler          *sampler
+	repanic          bool
+	summaryOnClose   bool
+	levelCounts      [DISABLED]atomic.Uint64
+	severities       map[LogLevel]int
+	numericLevels    bool
+	levelAlign       Alignment
+	levelWidth       int
+	indentMultiline  bool
+	levelColors      map[LogLevel]string
+	colorMode        colorMode // see SetColorEnabled
+	envColor         colorMode // from NO_COLOR and FORCE_COLOR at creation
+	redactKeys       map[string]bool
+	fieldAllowlist   map[string]bool
+	httpBodyLimit    int
+	httpPanicDetails bool
+	onceKeys         map[string]struct{}
+	everySites       map[string]*everySite
+	lastValues       map[string]string // see LogOnChange
+	spanDepth        int               // open spans, see Span
+	tableRowLimit    int
+	diffLineLimit    int
+	sqlQueryLimit    int
+	sqlRedactArgs    bool
+	autoData         bool
+	buildFields      []Field // see SetBuildInfo
+	strictFormat     bool
+	gaugeHook        func(name string, value float64)
+	classifiers      []func(error) map[string]interface{}
+	version          string
+	envPrefixes      []string
+	mu               sync.Mutex
 }
 
 // NewLogger creates a new Logger instance with the given console and file log levels.
 // Console output always writes to os.Stdout; file output is optional.
 func NewLogger(consoleLevel, fileLevel LogLevel) *Logger {
-	return &Logger{
-		consoleLevel: consoleLevel,
-		fileLevel:    fileLevel,
-		console:      log.New(os.Stdout, "", 0),
+	c := &core{
+		consoleLevel:    consoleLevel,
+		fileLevel:       fileLevel,
+		console:         os.Stdout,
+		stderr:          os.Stderr,
+		stackLevel:      DISABLED,
+		stderrLevel:     DISABLED,
+		sanitizeConsole: true,
+		fallbackStderr:  true,
+		envColor:        envColorMode(),
+		start:           time.Now(),
+	}
+	setFinalizer(c)
+	return &Logger{core: c}
+}
+
+// WithFields returns a child logger that adds fields to every entry it writes.
+// The child shares outputs and configuration with l.
+func (l *Logger) WithFields(fields ...Field) *Logger {
+	merged := make([]Field, 0, len(l.fields)+len(fields))
+	merged = append(merged, l.fields...)
+	merged = append(merged, fields...)
+	return &Logger{core: l.core, fields: merged, name: l.name}
+}
+
+// Named returns a child logger for a component. The name is shown as a "[name]" prefix
+// in text output and as a "logger" field in JSON. Names of nested children are joined
+// with dots (e.g. "db.pool").
+func (l *Logger) Named(name string) *Logger {
+	if l.name != "" {
+		name = l.name + "." + name
 	}
+	return &Logger{core: l.core, fields: l.fields, name: name}
+}
+
+// defaultLogFileName is the name of the log file created when none is configured.
+const defaultLogFileName = "out.log"
+
+// SetDefaultLogFileName sets the name of the log file created automatically on first use
+// when no file is configured ("out.log" by default).
+func (l *Logger) SetDefaultLogFileName(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.defaultFileName = name
+}
+
+// SetDefaultLogDir sets the directory of the automatically created log file
+// (the working directory by default).
+func (l *Logger) SetDefaultLogDir(dir string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.defaultDir = dir
 }
 
-// initDefaultLogFile initializes a default log file named "out.log" in the working directory.
+// LogFileMode controls how an existing log file is opened.
+type LogFileMode int
+
+// Available log file modes.
+const (
+	LogFileAppend   LogFileMode = iota // keep existing content (default)
+	LogFileTruncate                    // start with an empty file
+)
+
+// flag returns the os.OpenFile flags added for m.
+func (m LogFileMode) flag() int {
+	if m == LogFileTruncate {
+		return os.O_TRUNC
+	}
+	return 0
+}
+
+// SetLogFileMode sets whether files opened by later SetLogFile calls and the default log
+// file keep their existing content or are truncated. Files reopened after rotation or
+// deletion, per-level files and rotating files always append.
+func (l *Logger) SetLogFileMode(mode LogFileMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fileMode = mode
+}
+
+// initDefaultLogFile initializes the default log file ("out.log" in the working directory
+// unless changed with SetDefaultLogFileName and SetDefaultLogDir).
 // Must be called with l.mu held.
 func (l *Logger) initDefaultLogFile() error {
-	dir, err := os.Getwd()
+	dir := l.defaultDir
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		dir = wd
+	}
+	name := l.defaultFileName
+	if name == "" {
+		name = defaultLogFileName
+	}
+	return l.openLogFileFlag(filepath.Join(dir, name), l.fileMode.flag())
+}
+
+// samePath reports whether a and b name the same file, resolving relative paths and
+// symbolic links.
+func samePath(a, b string) bool {
+	return resolvePath(a) == resolvePath(b)
+}
+
+// resolvePath returns the absolute form of path with symbolic links resolved. For a file
+// that does not exist yet, the links of its directory are resolved.
+func resolvePath(path string) string {
+	abs, err := filepath.Abs(path)
 	if err != nil {
-		return err
+		return path
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
 	}
-	return l.openLogFile(filepath.Join(dir, "out.log"))
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		return filepath.Join(dir, filepath.Base(abs))
+	}
+	return abs
 }
 
-// openLogFile opens (or creates) the log file at path.
-// Must be called with l.mu held.
-func (l *Logger) openLogFile(path string) error {
+// openFile opens (or creates) the file at path for appending, creating directories if needed.
+func openFile(path string) (*os.File, error) {
+	return openFileFlag(path, 0)
+}
+
+// openFileFlag is like openFile with extra os.OpenFile flags, e.g. os.O_TRUNC.
+func openFileFlag(path string, flag int) (*os.File, error) {
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create log directory %q: %w", dir, err)
+		return nil, fmt.Errorf("failed to create log directory %q: %w", dir, err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND|flag, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file %q: %w", path, err)
 	}
+	return file, nil
+}
+
+// openLogFile opens (or creates) the log file at path for appending.
+// Must be called with l.mu held.
+func (l *Logger) openLogFile(path string) error {
+	return l.openLogFileFlag(path, 0)
+}
 
-	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+// openLogFileFlag is like openLogFile with extra os.OpenFile flags.
+// Must be called with l.mu held.
+func (l *Logger) openLogFileFlag(path string, flag int) error {
+	_ = l.flushFile()
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	file, err := openFileFlag(path, flag)
 	if err != nil {
-		return fmt.Errorf("failed to open log file %q: %w", path, err)
+		return err
 	}
 
 	l.logFile = file
-	l.file = log.New(file, "", 0)
+	l.logPath = path
+	l.file = file
 	return nil
 }
 
 // SetLogFile sets the path for the log file, creating directories if needed.
+// Setting the path of the file already in use, also through a symbolic link, keeps the
+// open file and logs an INFO notice instead of opening it a second time; a previously
+// opened file is closed.
 func (l *Logger) SetLogFile(path string) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	return l.openLogFile(path)
+
+	if l.logFile != nil {
+		if samePath(path, l.logPath) {
+			l.emit(time.Now(), INFO, fmt.Sprintf("log file %q is already configured, ignoring duplicate", path), nil)
+			return nil
+		}
+		prev := l.logFile
+		if err := l.openLogFileFlag(path, l.fileMode.flag()); err != nil {
+			return err
+		}
+		prev.Close()
+		return nil
+	}
+	return l.openLogFileFlag(path, l.fileMode.flag())
 }
 
-// Close safely closes the log file if it was opened.
-func (l *Logger) Close() {
+// SetConsoleWriter replaces the console destination (os.Stdout by default).
+// Wrap w with NewColorWriter to strip colors when it is not a terminal.
+// A nil writer disables console output.
+func (l *Logger) SetConsoleWriter(w io.Writer) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	if l.logFile != nil {
+	if l.consoleAsync != nil {
+		l.consoleAsync.close()
+		l.consoleAsync = nil
+	}
+	if w == nil {
+		l.console = nil
+		return
+	}
+	l.console = w
+}
+
+// SetFileWriter replaces the file destination with w, e.g. a *RotatingFile.
+// If w implements io.Closer it is closed by Close. A nil writer restores the
+// default "out.log" behavior.
+func (l *Logger) SetFileWriter(w io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_ = l.flushFile()
+	if l.logFile != nil && w != io.Writer(l.logFile) {
 		l.logFile.Close()
-		l.logFile = nil
 	}
+	l.logFile = nil
+	l.logPath = ""
+	l.file = w
+}
+
+// Close runs the OnClose callbacks, then safely closes the log file (or file writer),
+// any per-level files and sinks.
+func (l *Logger) Close() {
+	_, _ = l.CloseWithTimeout(0)
+}
+
+// CloseWithTimeout closes the logger like Close, but waits at most d for the non-blocking
+// console queue (see SetConsoleNonBlocking) to drain; a d <= 0 waits indefinitely.
+// It returns the number of console entries dropped, including those still queued at the
+// deadline, and ErrCloseTimeout if the queue could not be drained in time.
+func (l *Logger) CloseWithTimeout(d time.Duration) (int, error) {
+	l.runCloseHooks()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var dropped uint64
+	var err error
+	l.releaseStartup()
+	l.reportSampled()
+	l.setGroupIdle(0)
+	l.resume()
+	if l.summaryOnClose {
+		l.writeSummary()
+	}
+	l.stopSync()
+	l.stopEventReporter()
+	l.stopMemStats()
+	if l.durability.mode == osBuffered {
+		_ = l.flushFile()
+	} else {
+		_ = l.syncFile()
+	}
+	if c, ok := l.file.(io.Closer); ok {
+		c.Close()
+	}
+	l.logFile = nil
+	l.closeLevelFiles()
+	l.closeSinks()
+	if l.consoleAsync != nil {
+		var drained bool
+		dropped, drained = l.consoleAsync.closeTimeout(d)
+		if drained {
+			l.console = l.consoleAsync.w
+		} else {
+			// The console is stuck; stop writing to it.
+			l.console = nil
+			err = ErrCloseTimeout
+		}
+		l.consoleAsync = nil
+	}
+	if l.eventLog != nil {
+		l.eventLog.close()
+		l.eventLog = nil
+	}
+	if l.socket != nil {
+		l.socket.close()
+		l.socket = nil
+	}
+	if l.netOutput != nil {
+		l.netOutput.close()
+		l.netOutput = nil
+	}
+	if l.journal != nil {
+		l.journal.close()
+		l.journal = nil
+	}
+	l.bg.Wait()
+	return int(dropped), err
 }
 
 // Log writes a formatted message at the given log level to both console and file (if enabled).
+// It is the entry point for levels computed at runtime; the level-named helpers wrap it.
 func (l *Logger) Log(level LogLevel, format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	levelStr := levelToString(level)
-	now := time.Now().Format("02/01/2006 15:04:05.000000")
+	msg, fields := l.formatArgs(format, args)
+	l.output(level, msg, fields)
+}
+
+// formatArgs renders a printf-style call into a message and, with SetAutoData, a data field.
+// With SetStrictFormat, formatting mistakes are reported first.
+func (l *Logger) formatArgs(format string, args []interface{}) (string, []Field) {
+	if len(args) == 1 && l.isAutoData(format, args[0]) {
+		return format, []Field{Any("data", args[0])}
+	}
+	msg := fmt.Sprintf(format, args...)
+	l.checkFormat(format, msg, args)
+	return msg, nil
+}
 
+// Event writes msg at the given log level followed by the typed fields rendered as key=value pairs.
+func (l *Logger) Event(level LogLevel, msg string, fields ...Field) {
+	l.output(level, msg, fields)
+}
+
+// LogAt is like Log but stamps the entry with t instead of the current time,
+// e.g. when importing or replaying historical events.
+func (l *Logger) LogAt(t time.Time, level LogLevel, format string, args ...interface{}) {
+	msg, fields := l.formatArgs(format, args)
+	l.outputAt(t, level, msg, fields)
+}
+
+// output writes a single entry to all enabled destinations.
+func (l *Logger) output(level LogLevel, message string, fields []Field) {
+	l.outputAt(time.Now(), level, message, fields)
+}
+
+// outputAt writes a single entry with timestamp t to all enabled destinations.
+func (l *Logger) outputAt(t time.Time, level LogLevel, message string, fields []Field) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	// Initialize default log file if file logging is not yet configured.
-	if l.logFile == nil && l.file == nil {
+	fields, ok := l.sample(level, message, fields)
+	if !ok {
+		return
+	}
+	l.emit(t, level, message, fields)
+}
+
+// sample applies the sampler, if any, to message. It reports false if the entry must be
+// dropped and otherwise returns fields with the count of entries dropped since the last one.
+// Must be called with l.mu held.
+func (l *Logger) sample(level LogLevel, message string, fields []Field) ([]Field, bool) {
+	if l.sampler == nil {
+		return fields, true
+	}
+	if l.sampler.full(message) {
+		l.reportSampled()
+		l.sampler.counts = make(map[string]*sampleCount)
+	}
+	ok, dropped := l.sampler.check(level, message)
+	if !ok {
+		return nil, false
+	}
+	if dropped > 0 {
+		fields = append(fields[:len(fields):len(fields)], Int("dropped", dropped))
+	}
+	return fields, true
+}
+
+// emit captures a single entry and writes it to all enabled destinations.
+// Must be called with l.mu held.
+func (l *Logger) emit(t time.Time, level LogLevel, message string, fields []Field) {
+	e := l.newEntry(t, level, message, fields)
+	l.writeEntry(&e)
+}
+
+// newEntry captures an entry with the logger's fields, name, caller and stack.
+// Must be called with l.mu held.
+func (l *Logger) newEntry(t time.Time, level LogLevel, message string, fields []Field) entry {
+	if len(l.fields) > 0 {
+		fields = append(l.fields[:len(l.fields):len(l.fields)], fields...)
+	}
+	if len(l.buildFields) > 0 {
+		fields = append(l.buildFields[:len(l.buildFields):len(l.buildFields)], fields...)
+	}
+	fields = appendTraceID(fields)
+	e := entry{time: t, level: level, message: message, fields: fields, name: l.name}
+	if l.callerMode != CallerOff {
+		e.caller = callerInfo(l.callerMode)
+	}
+	if l.includePackage {
+		e.pkg = callerPackage()
+	}
+	if l.shouldLog(level, l.stackLevel) {
+		e.stack = stackTrace()
+	}
+	return e
+}
+
+// filterFields applies the error classifiers, the field allowlist and redaction to fields.
+// Must be called with l.mu held.
+func (l *Logger) filterFields(fields []Field) []Field {
+	fields = l.classifyFields(fields)
+	fields = l.allowFields(fields)
+	return l.redactFields(fields)
+}
+
+// writeEntry renders e and writes it to all enabled destinations.
+// Must be called with l.mu held.
+func (l *Logger) writeEntry(e *entry) {
+	if l.startup != nil && !e.audit {
+		l.startup.hold(e)
+		return
+	}
+	if l.paused && !e.audit {
+		if l.pauseBuf != nil {
+			l.pauseBuf.hold(e)
+		}
+		return
+	}
+	if l.groupIdle > 0 && !e.audit && !e.grouped {
+		l.holdGroupEntry(e)
+		return
+	}
+
+	// Filter fields only now, so held entries follow the configuration they are written with.
+	e.fields = l.filterFields(e.fields)
+
+	if l.monotonic {
+		e.time = l.monotonicTime(e.time)
+	}
+	level, t := e.level, e.time
+	if l.includeSequence {
+		e.seq = l.seq.Add(1)
+	}
+	if l.crash != nil {
+		l.recordCrashEntry(e)
+	}
+
+	// Initialize default log file if file logging is not yet configured. A configured
+	// file that could not be reopened (see Rotate) is not replaced by the default one.
+	if l.logFile == nil && l.file == nil && l.logPath == "" && l.fileLevel != DISABLED {
 		_ = l.initDefaultLogFile()
 	}
 
+	// Backdated entries (LogAt, Replay) are checked against the current time.
+	if l.reopenOnMissing {
+		l.checkLogFile(time.Now())
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	fileLevel, consoleLevel := l.fileLevel, l.consoleLevel
+	if l.levelSchedule != nil {
+		sched := l.scheduledLevel(time.Now())
+		fileLevel, consoleLevel = overrideLevel(fileLevel, sched), overrideLevel(consoleLevel, sched)
+	}
+	if e.hasMinLevel {
+		fileLevel, consoleLevel = overrideLevel(fileLevel, e.minLevel), overrideLevel(consoleLevel, e.minLevel)
+	}
+
 	// Write to file.
-	if l.file != nil && shouldLog(level, l.fileLevel) {
-		l.file.Printf("%s | %s | %s", now, levelStr, message)
+	wroteFile := false
+	if l.file != nil && (e.audit || l.shouldLog(level, fileLevel)) {
+		*buf = l.formatFile((*buf)[:0], e)
+		l.writeFileDurable(*buf)
+		if e.audit && l.durability.mode != syncEach {
+			_ = l.syncFile()
+		}
+		wroteFile = true
+	}
+	written := wroteFile
+
+	// Mirror to the per-level file, if any, unless it is the main file that already got the entry.
+	if lf := l.levelFiles[level]; lf != nil {
+		if lf.template != "" {
+			l.rollLevelFile(lf, level, t)
+		}
+		if lf.file != nil && !(wroteFile && lf.path == l.logPath) {
+			*buf = l.formatFile((*buf)[:0], e)
+			_, _ = lf.file.Write(*buf)
+			written = true
+		}
+	}
+
+	// Report to the Windows Event Log, if configured.
+	if l.eventLog != nil {
+		if etype, ok := eventType(level); ok {
+			*buf = e.appendMessage((*buf)[:0], sanitizeOff, false)
+			_ = l.eventLog.report(etype, string(*buf))
+			written = true
+		}
+	}
+
+	// Mirror file entries to the Unix socket and network collector, if configured.
+	if (l.socket != nil || l.netOutput != nil) && (e.audit || l.shouldLog(level, fileLevel)) {
+		*buf = l.format((*buf)[:0], e, l.fileFormat, false)
+		if l.socket != nil {
+			l.socket.write(*buf)
+		}
+		if l.netOutput != nil {
+			l.netOutput.write(*buf)
+		}
+		written = true
+	}
+
+	// Send file entries to the systemd journal, if configured.
+	if l.journal != nil && (e.audit || l.shouldLog(level, fileLevel)) {
+		*buf = appendJournalEntry((*buf)[:0], e)
+		_ = l.journal.send(*buf)
+		written = true
 	}
 
 	// Write to console.
-	if l.console != nil && shouldLog(level, l.consoleLevel) {
-		var color string
-		switch level {
-		case DEBUG:
-			color = yellow
-		case INFO:
-			color = blue
-		case SUCCESS:
-			color = green
-		case FAIL, ERROR:
-			color = red
+	if l.console != nil && !e.skipConsole && (e.audit || l.shouldLog(level, consoleLevel)) {
+		*buf = l.format((*buf)[:0], e, l.consoleFormat, true)
+		if l.progressActive {
+			_, _ = l.console.Write(clearLine)
+			l.progressActive = false
+		}
+		switch w := l.consoleWriterFor(level); {
+		case w != l.console:
+			_, _ = w.Write(*buf)
+		case e.audit && l.consoleAsync != nil:
+			l.consoleAsync.writeBlocking(*buf)
 		default:
-			color = yellow
+			_, _ = w.Write(*buf)
 		}
-		l.console.Printf("%s%s | %s |%s %s", now, color, levelStr, reset, message)
+		written = true
+	}
+
+	// Write to additional sinks.
+	if len(l.sinks) > 0 && l.writeSinks(buf, e) {
+		written = true
+	}
+
+	if l.writeFallback(buf, e) || written {
+		l.countLevel(level)
 	}
 }
 
@@ -176,10 +726,12 @@ func levelToString(level LogLevel) string {
 	}
 }
 
-// shouldLog checks if a given log level meets the configured minimum level.
-func shouldLog(msgLevel, minLevel LogLevel) bool {
-	if msgLevel == DISABLED {
+// shouldLog checks if a given log level meets the configured minimum level,
+// comparing their severities (see SetSeverity).
+// Must be called with l.mu held.
+func (l *Logger) shouldLog(msgLevel, minLevel LogLevel) bool {
+	if msgLevel == DISABLED || minLevel == DISABLED {
 		return false
 	}
-	return msgLevel >= minLevel
+	return l.severity(msgLevel) >= l.severity(minLevel)
 }
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"io"
+	"testing"
+)
+
+// newBenchLogger returns a logger writing f to a discarding console and nothing to files.
+func newBenchLogger(b *testing.B, f Format) *Logger {
+	b.Helper()
+	l := NewLogger(DEBUG, DISABLED)
+	l.SetConsoleWriter(io.Discard)
+	l.SetConsoleFormat(f)
+	b.Cleanup(l.Close)
+	return l
+}
+
+func BenchmarkLogText(b *testing.B) {
+	l := newBenchLogger(b, FormatText)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("request %d done", i)
+	}
+}
+
+func BenchmarkLogTextFields(b *testing.B) {
+	l := newBenchLogger(b, FormatText)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Event(INFO, "request done", Str("path", "/users"), Int("status", 200), Bool("cached", true))
+	}
+}
+
+func BenchmarkLogJSON(b *testing.B) {
+	l := newBenchLogger(b, FormatJSON)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("request %d done", i)
+	}
+}
+
+func BenchmarkLogJSONFields(b *testing.B) {
+	l := newBenchLogger(b, FormatJSON)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Event(INFO, "request done", Str("path", "/users"), Int("status", 200), Bool("cached", true))
+	}
+}
+
+func BenchmarkLogFiltered(b *testing.B) {
+	l := newBenchLogger(b, FormatText)
+	l.SetConsoleLevel(ERROR)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Debug("request %d done", i)
+	}
+}
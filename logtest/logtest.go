@@ -0,0 +1,93 @@
+// Package logtest provides helpers for asserting on log output in tests.
+// It is kept separate so the logger package does not import testing.
+package logtest
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dozerokz/logger"
+)
+
+// Entry is a log entry captured by a Recorder.
+type Entry struct {
+	Time    time.Time
+	Level   logger.LogLevel
+	Message string
+}
+
+// Recorder captures the entries of a logger, at all levels, regardless of its
+// console and file levels.
+type Recorder struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewRecorder attaches a Recorder to l for the duration of the test t.
+func NewRecorder(t testing.TB, l *logger.Logger) *Recorder {
+	t.Helper()
+	r := &Recorder{}
+	l.AddSink(r, logger.DEBUG, logger.FormatJSON)
+	t.Cleanup(func() { l.RemoveSink(r) })
+	return r
+}
+
+// Write records one entry. It implements logger.Sink.
+func (r *Recorder) Write(t time.Time, level logger.LogLevel, entry []byte) error {
+	var v struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(entry, &v); err != nil {
+		v.Message = strings.TrimSuffix(string(entry), "\n")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, Entry{Time: t, Level: level, Message: v.Message})
+	return nil
+}
+
+// Close implements logger.Sink; recorded entries stay available.
+func (r *Recorder) Close() error {
+	return nil
+}
+
+// Entries returns the entries recorded so far, oldest first.
+func (r *Recorder) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Entry(nil), r.entries...)
+}
+
+// Reset discards the recorded entries.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+}
+
+// AssertLogged fails t unless an entry at level whose message contains substr was
+// recorded. The failure message lists every recorded entry.
+func (r *Recorder) AssertLogged(t testing.TB, level logger.LogLevel, substr string) {
+	t.Helper()
+	entries := r.Entries()
+	for _, e := range entries {
+		if e.Level == level && strings.Contains(e.Message, substr) {
+			return
+		}
+	}
+
+	var sb strings.Builder
+	for _, e := range entries {
+		sb.WriteString("\n\t")
+		sb.WriteString(e.Level.String())
+		sb.WriteString(": ")
+		sb.WriteString(e.Message)
+	}
+	if len(entries) == 0 {
+		sb.WriteString(" none")
+	}
+	t.Errorf("no %s entry containing %q was logged; recorded entries:%s", level, substr, sb.String())
+}
@@ -0,0 +1,76 @@
+package logtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dozerokz/logger"
+)
+
+// fakeT records the failures reported through it.
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func newLogger(t *testing.T) *logger.Logger {
+	l := logger.NewLogger(logger.DISABLED, logger.DISABLED)
+	l.SetConsoleWriter(nil)
+	l.SetFallbackToStderr(false)
+	t.Cleanup(l.Close)
+	return l
+}
+
+func TestAssertLogged(t *testing.T) {
+	tests := []struct {
+		name    string
+		log     func(l *logger.Logger)
+		level   logger.LogLevel
+		substr  string
+		failure string // expected failure message, "" if the assertion passes
+	}{
+		{"found", func(l *logger.Logger) { l.Info("user alice signed in") }, logger.INFO, "alice", ""},
+		{"below console level", func(l *logger.Logger) { l.Debug("cache miss") }, logger.DEBUG, "miss", ""},
+		{"wrong level", func(l *logger.Logger) {
+			l.Info("saved")
+			l.Fail("quota exceeded")
+		}, logger.ERROR, "quota",
+			"no ERROR entry containing \"quota\" was logged; recorded entries:\n\tINFO: saved\n\tFAIL: quota exceeded"},
+		{"nothing logged", func(l *logger.Logger) {}, logger.SUCCESS, "done",
+			"no SUCCESS entry containing \"done\" was logged; recorded entries: none"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLogger(t)
+			r := NewRecorder(t, l)
+			tt.log(l)
+			ft := &fakeT{TB: t}
+			r.AssertLogged(ft, tt.level, tt.substr)
+
+			switch {
+			case tt.failure == "" && len(ft.errors) > 0:
+				t.Errorf("unexpected failure: %s", ft.errors[0])
+			case tt.failure != "" && (len(ft.errors) != 1 || ft.errors[0] != tt.failure):
+				t.Errorf("failures = %q, want %q", ft.errors, tt.failure)
+			}
+		})
+	}
+}
+
+func TestRecorderReset(t *testing.T) {
+	l := newLogger(t)
+	r := NewRecorder(t, l)
+	l.Info("one")
+	r.Reset()
+	l.Info("two")
+	if got := r.Entries(); len(got) != 1 || !strings.Contains(got[0].Message, "two") {
+		t.Errorf("entries after Reset = %+v", got)
+	}
+}
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"runtime"
+	"time"
+)
+
+// LogMemStats logs a "memory stats" entry at level with the heap in use (heap_alloc),
+// memory obtained from the OS (sys), the number of completed GC cycles (num_gc) and the
+// last and total GC pause times. The statistics are read on every call, and
+// runtime.ReadMemStats briefly stops the world, so call it sparingly.
+func (l *Logger) LogMemStats(level LogLevel) {
+	l.output(level, "memory stats", memStatsFields())
+}
+
+// SetMemStatsInterval logs memory stats (see LogMemStats) at INFO level every d until
+// Close. A non-positive d stops the periodic entries (the default).
+func (l *Logger) SetMemStatsInterval(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.setMemStatsInterval(d)
+}
+
+// setMemStatsInterval (re)starts or stops the periodic memory stats.
+// Must be called with l.mu held.
+func (l *Logger) setMemStatsInterval(d time.Duration) {
+	if d <= 0 {
+		d = 0
+	}
+	if d == l.memStatsInterval {
+		return
+	}
+	l.stopMemStats()
+	l.memStatsInterval = d
+	if d > 0 {
+		l.memStatsStop = make(chan struct{})
+		go l.memStatsLoop(d, l.memStatsStop)
+	}
+}
+
+// stopMemStats stops the periodic memory stats, if running.
+// Must be called with l.mu held.
+func (l *Logger) stopMemStats() {
+	if l.memStatsStop != nil {
+		close(l.memStatsStop)
+		l.memStatsStop = nil
+	}
+	l.memStatsInterval = 0
+}
+
+// memStatsLoop logs memory stats every interval until stop is closed.
+func (l *Logger) memStatsLoop(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			running := l.memStatsStop == stop
+			l.mu.Unlock()
+			if running {
+				l.LogMemStats(INFO)
+			}
+		}
+	}
+}
+
+// memStatsFields reads the runtime memory statistics as fields.
+func memStatsFields() []Field {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	var lastPause time.Duration
+	if m.NumGC > 0 {
+		lastPause = time.Duration(m.PauseNs[(m.NumGC+255)%256])
+	}
+	return []Field{
+		Bytes("heap_alloc", int64(m.HeapAlloc)),
+		Bytes("sys", int64(m.Sys)),
+		Int("num_gc", int(m.NumGC)),
+		Dur("last_pause", lastPause),
+		Dur("total_pause", time.Duration(m.PauseTotalNs)),
+	}
+}
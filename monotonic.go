@@ -0,0 +1,30 @@
+package logger
+
+import "time"
+
+// SetMonotonicTimestamps derives entry timestamps from the monotonic clock: the wall-clock
+// time at logger creation plus the monotonic time elapsed since. Timestamps then never go
+// backward, not even when the system clock is stepped (e.g. by NTP), and entries written
+// concurrently are stamped in the order they are written. The price is drift from the
+// system clock across such adjustments. Times passed to LogAt are kept as given.
+func (l *Logger) SetMonotonicTimestamps(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.monotonic = enabled
+}
+
+// monotonicTime maps t, read with time.Now, onto the monotonic timeline of the logger.
+// Times without a monotonic clock reading are returned unchanged.
+// Must be called with l.mu held.
+func (l *Logger) monotonicTime(t time.Time) time.Time {
+	if t == t.Round(0) {
+		// No monotonic reading: an explicit time from LogAt.
+		return t
+	}
+	t = l.start.Add(t.Sub(l.start))
+	if t.Before(l.lastTime) {
+		t = l.lastTime
+	}
+	l.lastTime = t
+	return t
+}
@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+// maxFlattenDepth bounds how many levels of nested objects are flattened into dotted
+// keys in text output; deeper objects are written as inline JSON.
+const maxFlattenDepth = 8
+
+// appendAnyText appends an Any field as key=value in the text format. A value that
+// encodes to a JSON object (a struct or map) is flattened into dotted keys, e.g.
+// "user.id=5 user.name=alice"; other values are written as inline JSON.
+func (f Field) appendAnyText(buf []byte) []byte {
+	data := f.appendAnyJSON(nil)
+	if len(data) == 0 || data[0] != '{' {
+		buf = appendTextKey(buf, f.Key)
+		return append(buf, data...)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		buf = appendTextKey(buf, f.Key)
+		return append(buf, data...)
+	}
+	return appendFlattened(buf, f.Key, v, 0)
+}
+
+// appendFlattened appends v under key, descending into non-empty objects with dotted keys.
+func appendFlattened(buf []byte, key string, v interface{}, depth int) []byte {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 || depth >= maxFlattenDepth {
+			break
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for i, k := range keys {
+			if i > 0 {
+				buf = append(buf, ' ')
+			}
+			buf = appendFlattened(buf, key+"."+k, v[k], depth+1)
+		}
+		return buf
+	case string:
+		buf = appendTextKey(buf, key)
+		if v == "" || needsQuote(v) {
+			return strconv.AppendQuote(buf, v)
+		}
+		return append(buf, v...)
+	}
+	buf = appendTextKey(buf, key)
+	data, err := json.Marshal(v)
+	if err != nil {
+		return append(buf, "null"...)
+	}
+	return append(buf, data...)
+}
+
+// appendTextKey appends "key=".
+func appendTextKey(buf []byte, key string) []byte {
+	buf = append(buf, key...)
+	return append(buf, '=')
+}
@@ -0,0 +1,51 @@
+package logger
+
+// LogOnce logs a formatted message at the given level only the first time key is seen
+// for the lifetime of the logger (or until ResetOnce).
+func (l *Logger) LogOnce(level LogLevel, key string, format string, args ...interface{}) {
+	l.mu.Lock()
+	if _, seen := l.onceKeys[key]; seen {
+		l.mu.Unlock()
+		return
+	}
+	if l.onceKeys == nil {
+		l.onceKeys = make(map[string]struct{})
+	}
+	l.onceKeys[key] = struct{}{}
+	l.mu.Unlock()
+
+	msg, fields := l.formatArgs(format, args)
+	l.output(level, msg, fields)
+}
+
+// ResetOnce forgets all keys seen by the *Once helpers.
+func (l *Logger) ResetOnce() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onceKeys = nil
+}
+
+// DebugOnce logs a message at DEBUG level once per key.
+func (l *Logger) DebugOnce(key, format string, args ...interface{}) {
+	l.LogOnce(DEBUG, key, format, args...)
+}
+
+// InfoOnce logs a message at INFO level once per key.
+func (l *Logger) InfoOnce(key, format string, args ...interface{}) {
+	l.LogOnce(INFO, key, format, args...)
+}
+
+// SuccessOnce logs a message at SUCCESS level once per key.
+func (l *Logger) SuccessOnce(key, format string, args ...interface{}) {
+	l.LogOnce(SUCCESS, key, format, args...)
+}
+
+// FailOnce logs a message at FAIL level once per key.
+func (l *Logger) FailOnce(key, format string, args ...interface{}) {
+	l.LogOnce(FAIL, key, format, args...)
+}
+
+// ErrorOnce logs a message at ERROR level once per key.
+func (l *Logger) ErrorOnce(key, format string, args ...interface{}) {
+	l.LogOnce(ERROR, key, format, args...)
+}
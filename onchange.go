@@ -0,0 +1,25 @@
+package logger
+
+// LogOnChange logs value for key at the given level only when it differs from the value
+// last seen for key, e.g. the status reported by a polling loop. The entry is
+// "<key> changed" with "old" and "new" fields; the first value seen for a key is logged
+// as "<key> set" with only "new".
+func (l *Logger) LogOnChange(key string, level LogLevel, value string) {
+	l.mu.Lock()
+	old, seen := l.lastValues[key]
+	if seen && old == value {
+		l.mu.Unlock()
+		return
+	}
+	if l.lastValues == nil {
+		l.lastValues = make(map[string]string)
+	}
+	l.lastValues[key] = value
+	l.mu.Unlock()
+
+	if !seen {
+		l.output(level, key+" set", []Field{Str("new", value)})
+		return
+	}
+	l.output(level, key+" changed", []Field{Str("old", old), Str("new", value)})
+}
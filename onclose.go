@@ -0,0 +1,22 @@
+package logger
+
+// OnClose registers fn to run when the logger is closed, before any output is flushed
+// or closed, so it can still log (e.g. a final "shutting down" entry) or flush its own
+// buffers. Callbacks run in reverse order of registration, once.
+func (l *Logger) OnClose(fn func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closeHooks = append(l.closeHooks, fn)
+}
+
+// runCloseHooks runs and clears the OnClose callbacks, last registered first.
+func (l *Logger) runCloseHooks() {
+	l.mu.Lock()
+	hooks := l.closeHooks
+	l.closeHooks = nil
+	l.mu.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+}
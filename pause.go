@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"strconv"
+	"time"
+)
+
+// Pause stops all output until Resume, without changing levels, e.g. to silence a noisy
+// bulk operation. Entries logged meanwhile are dropped, or held if SetPauseBuffer was
+// called. Audit entries are still written.
+func (l *Logger) Pause() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.paused {
+		return
+	}
+	l.paused = true
+	if l.pauseSize > 0 {
+		l.pauseBuf = &startupBuffer{size: l.pauseSize}
+	}
+}
+
+// Resume ends a Pause. Held entries are written first, in order; if the buffer
+// overflowed, a FAIL entry reports how many were dropped.
+func (l *Logger) Resume() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.resume()
+}
+
+// SetPauseBuffer holds up to size entries logged while paused and writes them on
+// Resume instead of dropping them, filtering their fields with the allowlist and
+// redaction in effect then. A size <= 0 drops them (the default). It applies from the
+// next Pause.
+func (l *Logger) SetPauseBuffer(size int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if size < 0 {
+		size = 0
+	}
+	l.pauseSize = size
+}
+
+// resume ends a pause and writes the held entries.
+// Must be called with l.mu held.
+func (l *Logger) resume() {
+	if !l.paused {
+		return
+	}
+	l.paused = false
+	pb := l.pauseBuf
+	l.pauseBuf = nil
+	if pb == nil {
+		return
+	}
+	for i := range pb.entries {
+		l.writeEntry(&pb.entries[i])
+	}
+	if pb.dropped > 0 {
+		l.emit(time.Now(), FAIL, "pause buffer full, dropped "+strconv.Itoa(pb.dropped)+" entries", nil)
+	}
+}
@@ -0,0 +1,97 @@
+package logger
+
+// Colors used by appendPrettyJSON.
+const (
+	jsonKeyColor     = "\033[36m" // cyan
+	jsonStringColor  = "\033[32m" // green
+	jsonLiteralColor = "\033[33m" // yellow: numbers, booleans and null
+)
+
+// SetPrettyJSON renders console JSON entries indented and syntax-highlighted when the
+// console is a terminal. Redirected console output and files keep compact one-line JSON.
+func (l *Logger) SetPrettyJSON(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prettyJSON = enabled
+}
+
+// consoleIsTerminal reports whether the console writer (behind the non-blocking queue,
+// if any) is a terminal.
+// Must be called with l.mu held.
+func (l *Logger) consoleIsTerminal() bool {
+	w := l.console
+	if l.consoleAsync != nil {
+		w = l.consoleAsync.w
+	}
+	return isTerminal(w)
+}
+
+// appendPrettyJSON appends the compact, valid JSON document src to buf indented by two
+// spaces, with keys, strings and literals colorized if color is set.
+func appendPrettyJSON(buf, src []byte, color bool) []byte {
+	indent := 0
+	newline := func(buf []byte) []byte {
+		buf = append(buf, '\n')
+		for i := 0; i < indent; i++ {
+			buf = append(buf, "  "...)
+		}
+		return buf
+	}
+
+	for i := 0; i < len(src); i++ {
+		switch c := src[i]; c {
+		case '"':
+			j := i + 1
+			for ; j < len(src) && src[j] != '"'; j++ {
+				if src[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(src) {
+				j = len(src) - 1
+			}
+			code := jsonStringColor
+			if j+1 < len(src) && src[j+1] == ':' {
+				code = jsonKeyColor
+			}
+			buf = appendColored(buf, src[i:j+1], code, color)
+			i = j
+		case '{', '[':
+			if i+1 < len(src) && (src[i+1] == '}' || src[i+1] == ']') {
+				buf = append(buf, c, src[i+1])
+				i++
+				continue
+			}
+			buf = append(buf, c)
+			indent++
+			buf = newline(buf)
+		case '}', ']':
+			indent--
+			buf = newline(buf)
+			buf = append(buf, c)
+		case ',':
+			buf = append(buf, ',')
+			buf = newline(buf)
+		case ':':
+			buf = append(buf, ": "...)
+		default:
+			j := i
+			for j < len(src) && src[j] != ',' && src[j] != '}' && src[j] != ']' {
+				j++
+			}
+			buf = appendColored(buf, src[i:j], jsonLiteralColor, color)
+			i = j - 1
+		}
+	}
+	return buf
+}
+
+// appendColored appends p, wrapped in code and reset if color is set.
+func appendColored(buf, p []byte, code string, color bool) []byte {
+	if !color {
+		return append(buf, p...)
+	}
+	buf = append(buf, code...)
+	buf = append(buf, p...)
+	return append(buf, reset...)
+}
@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// progressBarWidth is the number of cells in the Progress bar.
+const progressBarWidth = 30
+
+// clearLine returns the cursor to the start of the line and clears it, removing a progress bar.
+var clearLine = []byte("\r\033[K")
+
+// Progress reports progress of the task label as current out of total. On a terminal
+// console it redraws a progress bar in place on a single line; other log entries clear
+// that line first, so they are not mixed with it. Otherwise, and always in the log file,
+// an INFO entry is logged at every 10% milestone. Reaching total ends the bar.
+func (l *Logger) Progress(current, total int, label string) {
+	t := time.Now()
+	if total <= 0 {
+		total = 1
+	}
+	if current > total {
+		current = total
+	}
+	if current < 0 {
+		current = 0
+	}
+	pct := current * 100 / total
+	done := current == total
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	milestone := pct / 10 * 10
+	last, seen := l.progress[label]
+	if l.progress == nil {
+		l.progress = make(map[string]int)
+	}
+	if done {
+		delete(l.progress, label)
+	} else {
+		l.progress[label] = milestone
+	}
+
+	bar := l.console != nil && !l.paused && l.shouldLog(INFO, l.consoleLevel) && l.consoleIsTerminal()
+	if bar {
+		buf := getBuffer()
+		*buf = appendProgressBar((*buf)[:0], current, total, pct, label, l.sanitizeConsole)
+		if done {
+			*buf = append(*buf, '\n')
+		}
+		_, _ = l.console.Write(*buf)
+		putBuffer(buf)
+		l.progressActive = !done
+	}
+
+	if !seen || milestone > last || done {
+		e := l.newEntry(t, INFO, label+" "+strconv.Itoa(pct)+"% ("+strconv.Itoa(current)+"/"+strconv.Itoa(total)+")", nil)
+		e.skipConsole = bar
+		l.writeEntry(&e)
+	}
+}
+
+// appendProgressBar appends "\rlabel [=====>    ]  42% (42/100)" followed by a clear-to-end-of-line.
+// With sanitize, control characters in label are escaped.
+func appendProgressBar(buf []byte, current, total, pct int, label string, sanitize bool) []byte {
+	filled := current * progressBarWidth / total
+	buf = append(buf, '\r')
+	if sanitize {
+		buf = appendSanitized(buf, label, false)
+	} else {
+		buf = append(buf, label...)
+	}
+	buf = append(buf, " ["...)
+	buf = append(buf, strings.Repeat("=", filled)...)
+	if filled < progressBarWidth {
+		buf = append(buf, '>')
+		buf = append(buf, strings.Repeat(" ", progressBarWidth-filled-1)...)
+	}
+	buf = append(buf, "] "...)
+	if pct < 100 {
+		buf = append(buf, ' ')
+	}
+	if pct < 10 {
+		buf = append(buf, ' ')
+	}
+	buf = strconv.AppendInt(buf, int64(pct), 10)
+	buf = append(buf, "% ("...)
+	buf = strconv.AppendInt(buf, int64(current), 10)
+	buf = append(buf, '/')
+	buf = strconv.AppendInt(buf, int64(total), 10)
+	buf = append(buf, ')')
+	return append(buf, "\033[K"...)
+}
@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// SetRepanic configures whether Recover re-panics after logging a recovered panic.
+func (l *Logger) SetRepanic(repanic bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.repanic = repanic
+}
+
+// Recover recovers a panic, logs its value and stack trace at FAIL level and
+// re-panics if enabled with SetRepanic, writing the crash log first (see SetCrashLog).
+// It must be deferred directly:
+//
+//	defer log.Recover()
+func (l *Logger) Recover() {
+	if r := recover(); r != nil {
+		l.logPanic(r, nil)
+
+		l.mu.Lock()
+		repanic := l.repanic
+		if repanic {
+			// Nothing held by SetGoroutineGrouping may be lost when the program crashes.
+			l.writeGroups(time.Time{})
+			if l.crash != nil {
+				_ = l.writeCrashLog(time.Now(), r)
+			}
+		}
+		l.mu.Unlock()
+		if repanic {
+			panic(r)
+		}
+	}
+}
+
+// RecoverAndContinue recovers a panic and logs it like Recover, but never re-panics.
+// It must be deferred directly.
+func (l *Logger) RecoverAndContinue() {
+	if r := recover(); r != nil {
+		l.logPanic(r, nil)
+	}
+}
+
+// logPanic logs a recovered panic value and fields together with the current goroutine stack.
+func (l *Logger) logPanic(r interface{}, fields []Field) {
+	t := time.Now()
+	stack := strings.TrimSpace(string(debug.Stack()))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// The panic is written at once, after the entries leading up to it.
+	l.writeGroups(time.Time{})
+	e := l.newEntry(t, FAIL, fmt.Sprintf("panic: %v", r), fields)
+	e.stack = stack
+	e.grouped = true
+	l.writeEntry(&e)
+}
@@ -0,0 +1,152 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// redactedValue replaces the value of redacted fields and headers.
+const redactedValue = "[REDACTED]"
+
+// defaultRedactKeys are the field keys and header names redacted unless changed with SetRedactKeys.
+var defaultRedactKeys = []string{
+	"authorization",
+	"proxy-authorization",
+	"cookie",
+	"set-cookie",
+	"x-api-key",
+	"api_key",
+	"password",
+	"secret",
+	"token",
+}
+
+// SetRedactKeys replaces the set of field keys and HTTP header names whose values are
+// written as "[REDACTED]". Matching is case-insensitive and also applies to the keys of
+// objects nested at any depth in Any values, e.g. a "password" map key or struct field
+// inside Any("user", u). Calling it with no keys disables redaction. By default Authorization, Proxy-Authorization, Cookie, Set-Cookie, X-Api-Key,
+// api_key, password, secret and token are redacted.
+func (l *Logger) SetRedactKeys(keys ...string) {
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.redactKeys = set
+}
+
+// isRedacted reports whether values under key must be redacted.
+// Must be called with l.mu held.
+func (l *Logger) isRedacted(key string) bool {
+	if l.redactKeys == nil {
+		key = strings.ToLower(key)
+		for _, k := range defaultRedactKeys {
+			if k == key {
+				return true
+			}
+		}
+		return false
+	}
+	return l.redactKeys[strings.ToLower(key)]
+}
+
+// redactFields returns fields with redacted values replaced, copying only if needed.
+// Must be called with l.mu held.
+func (l *Logger) redactFields(fields []Field) []Field {
+	var out []Field
+	for i, f := range fields {
+		var redacted Field
+		switch {
+		case l.isRedacted(f.Key):
+			redacted = Str(f.Key, redactedValue)
+		case f.kind == anyKind:
+			data, ok := l.redactNested(f)
+			if !ok {
+				continue
+			}
+			redacted = Any(f.Key, json.RawMessage(data))
+		default:
+			continue
+		}
+		if out == nil {
+			out = append([]Field(nil), fields...)
+		}
+		out[i] = redacted
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// redactNested returns the JSON encoding of the Any field f with the values of redacted
+// keys replaced at any depth, reporting false if there were none.
+// Must be called with l.mu held.
+func (l *Logger) redactNested(f Field) ([]byte, bool) {
+	data := f.appendAnyJSON(nil)
+	if len(data) == 0 || (data[0] != '{' && data[0] != '[') || !l.mentionsRedactedKey(data) {
+		return nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || !l.redactValue(v) {
+		return nil, false
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+// redactValue replaces the values of redacted keys in the objects of a decoded JSON
+// value, in place, and reports whether it replaced any.
+// Must be called with l.mu held.
+func (l *Logger) redactValue(v interface{}) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, elem := range v {
+			if l.isRedacted(k) {
+				v[k] = redactedValue
+				found = true
+			} else if l.redactValue(elem) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for _, elem := range v {
+			if l.redactValue(elem) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// mentionsRedactedKey reports whether the JSON encoding data may hold a redacted key,
+// so values without one are not decoded.
+// Must be called with l.mu held.
+func (l *Logger) mentionsRedactedKey(data []byte) bool {
+	lower := bytes.ToLower(data)
+	mentions := func(key string) bool {
+		return bytes.Contains(lower, []byte(`"`+key+`"`))
+	}
+	if l.redactKeys == nil {
+		for _, k := range defaultRedactKeys {
+			if mentions(k) {
+				return true
+			}
+		}
+		return false
+	}
+	for k := range l.redactKeys {
+		if mentions(k) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactNested(t *testing.T) {
+	type credentials struct {
+		User     string
+		Password string
+	}
+	tests := []struct {
+		name   string
+		format Format
+		field  Field
+		want   string
+	}{
+		{"map text", FormatText, Any("req", map[string]interface{}{"password": "hunter2", "user": "alice"}),
+			"req.password=[REDACTED] req.user=alice"},
+		{"struct text", FormatText, Any("login", credentials{"alice", "hunter2"}),
+			"login.Password=[REDACTED] login.User=alice"},
+		{"deep json", FormatJSON, Any("cfg", map[string]interface{}{"db": map[string]interface{}{"secret": "s3", "port": 5432}}),
+			`"cfg":{"db":{"port":5432,"secret":"[REDACTED]"}}`},
+		{"array json", FormatJSON, Any("users", []map[string]interface{}{{"name": "a", "token": "t1"}}),
+			`"users":[{"name":"a","token":"[REDACTED]"}]`},
+		{"unrelated value", FormatJSON, Any("note", map[string]string{"text": "my password is safe"}),
+			`"note":{"text":"my password is safe"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console := newTestLogger(t)
+			l.SetConsoleFormat(tt.format)
+			l.Event(INFO, "msg", tt.field)
+			out := console.String()
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("got %q, want it to contain %q", out, tt.want)
+			}
+			if strings.Contains(out, "hunter2") || strings.Contains(out, "s3") || strings.Contains(out, "t1") {
+				t.Errorf("secret written in clear: %q", out)
+			}
+		})
+	}
+}
+
+func TestRedactNestedDisabled(t *testing.T) {
+	l, console := newTestLogger(t)
+	l.SetRedactKeys()
+	l.Event(INFO, "msg", Any("req", map[string]string{"password": "hunter2"}))
+	if out := console.String(); !strings.Contains(out, "req.password=hunter2") {
+		t.Errorf("got %q, want the value unredacted", out)
+	}
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"os"
+	"time"
+)
+
+// reopenCheckInterval is how often the log file path is checked when reopen-on-missing is enabled.
+const reopenCheckInterval = time.Second
+
+// SetReopenOnMissing enables periodic checks (at most once per second) that the log file
+// still exists at its path. If it was deleted or moved away, e.g. by an external logrotate,
+// the file is reopened at the original path so new entries are not lost. If it cannot be
+// reopened, entries keep going to the old file and reopening is retried.
+func (l *Logger) SetReopenOnMissing(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reopenOnMissing = enabled
+}
+
+// checkLogFile reopens the log file if it no longer exists at l.logPath, or if it could
+// not be reopened before.
+// Must be called with l.mu held.
+func (l *Logger) checkLogFile(now time.Time) {
+	if l.logPath == "" || (l.logFile == nil && l.file != nil) || now.Sub(l.lastReopenCheck) < reopenCheckInterval {
+		return
+	}
+	l.lastReopenCheck = now
+
+	if l.logFile != nil {
+		pathInfo, err := os.Stat(l.logPath)
+		if err == nil {
+			fileInfo, ferr := l.logFile.Stat()
+			if ferr == nil && os.SameFile(pathInfo, fileInfo) {
+				return
+			}
+		} else if !os.IsNotExist(err) {
+			return
+		}
+	}
+
+	// Open the new file first: if that fails, the old one keeps receiving entries and
+	// the check is retried.
+	prev := l.logFile
+	if err := l.openLogFile(l.logPath); err != nil {
+		return
+	}
+	if prev != nil {
+		prev.Close()
+	}
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recheck makes the next entry check the log file again.
+func recheck(l *Logger) {
+	l.mu.Lock()
+	l.lastReopenCheck = time.Time{}
+	l.mu.Unlock()
+}
+
+func TestReopenFailureKeepsOldFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	moved := filepath.Join(dir, "moved.log")
+	l := NewLogger(DISABLED, DEBUG)
+	defer l.Close()
+	l.SetDefaultLogDir(dir)
+	if err := l.SetLogFile(path); err != nil {
+		t.Fatal(err)
+	}
+	l.SetReopenOnMissing(true)
+	l.Info("one")
+
+	// Moved away, with a directory blocking the path: reopening fails.
+	if err := os.Rename(path, moved); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	recheck(l)
+	l.Info("two")
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	recheck(l)
+	l.Info("three")
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	for file, want := range map[string][]string{moved: {"one", "two"}, path: {"three"}} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := textMessages(string(data)); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: got %q, want %q", filepath.Base(file), got, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, defaultLogFileName)); err == nil {
+		t.Errorf("default log file created")
+	}
+}
+
+func TestLostLogFileNotReplacedByDefault(t *testing.T) {
+	dir := t.TempDir()
+	l := NewLogger(DISABLED, DEBUG)
+	defer l.Close()
+	l.SetDefaultLogDir(dir)
+	if err := l.SetLogFile(filepath.Join(dir, "app.log")); err != nil {
+		t.Fatal(err)
+	}
+	// The state left by a rotation whose files could not be reopened.
+	l.mu.Lock()
+	l.logFile.Close()
+	l.logFile, l.file = nil, nil
+	l.mu.Unlock()
+
+	l.Info("entry")
+	if _, err := os.Stat(filepath.Join(dir, defaultLogFileName)); err == nil {
+		t.Errorf("default log file created")
+	}
+}
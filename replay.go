@@ -0,0 +1,198 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxReplayLine is the longest line Replay accepts.
+const maxReplayLine = 1 << 20
+
+// replayMetaKeys are JSON keys written by this package that Replay does not turn into fields.
+var replayMetaKeys = map[string]bool{
+	"time": true, "timestamp": true, "elapsed": true, "seq": true,
+	"level": true, "severity": true, "severity_number": true, "schema": true, "message": true,
+}
+
+// Replay reads entries previously written by a logger and writes them again through l,
+// with their original timestamps and levels, e.g. to convert a text log to JSON or to
+// feed recorded data to a sink under test. Each line is parsed as a JSON entry (including
+// FormatGCP) if it starts with '{', and as a text entry ("time | LEVEL | message")
+// otherwise; text lines that do not start an entry continue the previous message. JSON keys
+// other than the time, level and message become fields. Entries go through the usual
+// levels, sampling and redaction of l. Replay stops at the first line it cannot parse
+// and returns its error; earlier entries have been written.
+func (l *Logger) Replay(r io.Reader) error {
+	l.mu.Lock()
+	layout := l.timePrecision.timeLayout()
+	l.mu.Unlock()
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), maxReplayLine)
+
+	// A text entry is held until the next one starts, collecting continuation lines.
+	var pending *replayEntry
+	flush := func() {
+		if pending != nil {
+			l.outputAt(pending.time, pending.level, pending.message, pending.fields)
+			pending = nil
+		}
+	}
+
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "{") {
+			flush()
+			e, err := parseJSONEntry(sc.Bytes())
+			if err != nil {
+				return fmt.Errorf("replay line %d: %w", n, err)
+			}
+			l.outputAt(e.time, e.level, e.message, e.fields)
+			continue
+		}
+		if e, ok := parseTextEntry(line, layout); ok {
+			flush()
+			pending = e
+			continue
+		}
+		if pending == nil {
+			return fmt.Errorf("replay line %d: not a log entry: %q", n, line)
+		}
+		pending.message += "\n" + line
+	}
+	flush()
+	return sc.Err()
+}
+
+// replayEntry is an entry parsed by Replay.
+type replayEntry struct {
+	time    time.Time
+	level   LogLevel
+	message string
+	fields  []Field
+}
+
+// parseJSONEntry parses one JSON entry, keeping the order of its fields.
+func parseJSONEntry(line []byte) (*replayEntry, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("not a JSON object")
+	}
+
+	e := &replayEntry{level: INFO}
+	hasTime := false
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := tok.(string)
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		switch key {
+		case "time", "timestamp":
+			s, _ := v.(string)
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q", key, s)
+			}
+			e.time, hasTime = t, true
+		case "level", "severity":
+			s, _ := v.(string)
+			level, ok := levelFromString(s)
+			if !ok {
+				return nil, fmt.Errorf("unknown level %q", s)
+			}
+			e.level = level
+		case "message":
+			e.message, _ = v.(string)
+		default:
+			if !replayMetaKeys[key] {
+				e.fields = append(e.fields, replayField(key, v))
+			}
+		}
+	}
+	if !hasTime {
+		return nil, fmt.Errorf("missing time")
+	}
+	return e, nil
+}
+
+// replayField converts a decoded JSON value to a typed field where possible.
+func replayField(key string, v interface{}) Field {
+	switch v := v.(type) {
+	case string:
+		return Str(key, v)
+	case bool:
+		return Bool(key, v)
+	case json.Number:
+		if n, err := strconv.Atoi(v.String()); err == nil {
+			return Int(key, n)
+		}
+		if f, err := v.Float64(); err == nil {
+			return Float64(key, f)
+		}
+		return Str(key, v.String())
+	default:
+		return Any(key, v)
+	}
+}
+
+// parseTextEntry parses the first line of a text entry written with the time layout.
+// Alignment padding and numeric severities after the level are accepted.
+func parseTextEntry(line, layout string) (*replayEntry, bool) {
+	parts := strings.SplitN(line, " | ", 3)
+	if len(parts) < 2 {
+		return nil, false
+	}
+	t, err := time.ParseInLocation(layout, parts[0], time.Local)
+	if err != nil {
+		return nil, false
+	}
+	name, rest := parts[1], ""
+	if len(parts) == 3 {
+		rest = parts[2]
+	} else {
+		// An entry with an empty message ends in " |".
+		name = strings.TrimSuffix(name, " |")
+	}
+	name = strings.TrimSpace(name)
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = name[:i]
+	}
+	level, ok := levelFromString(name)
+	if !ok {
+		return nil, false
+	}
+	return &replayEntry{time: t, level: level, message: rest}, true
+}
+
+// levelFromString returns the level named s (case-insensitive), accepting WARN and the
+// Google Cloud Logging severities written by FormatGCP.
+func levelFromString(s string) (LogLevel, bool) {
+	switch strings.ToUpper(s) {
+	case "DEBUG":
+		return DEBUG, true
+	case "INFO", "DEFAULT":
+		return INFO, true
+	case "SUCCESS", "NOTICE":
+		return SUCCESS, true
+	case "FAIL", "WARN", "WARNING":
+		return FAIL, true
+	case "ERROR", "CRITICAL", "ALERT", "EMERGENCY":
+		return ERROR, true
+	}
+	return 0, false
+}
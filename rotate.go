@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// backupTimeFormat is the timestamp layout used in rotated file names.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// Rotate closes the current log file, renames it with a timestamp suffix
+// (e.g. "out.2025-07-28T14-47-48.000.log") and opens a fresh file at the original path.
+// Existing backups are never overwritten: if the name is taken, the timestamp is moved
+// forward to the next free millisecond.
+// If the file writer is a *RotatingFile, its own Rotate is used.
+// It is safe to call concurrently with logging. If no log file is open, Rotate does nothing.
+func (l *Logger) Rotate() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_ = l.flushFile()
+	if r, ok := l.file.(*RotatingFile); ok {
+		return r.Rotate()
+	}
+	return l.rotate()
+}
+
+// rotate performs the rotation.
+// Must be called with l.mu held.
+func (l *Logger) rotate() error {
+	if l.logFile == nil {
+		return nil
+	}
+
+	path := l.logPath
+	if err := l.logFile.Close(); err != nil {
+		return fmt.Errorf("failed to close log file %q: %w", path, err)
+	}
+	l.logFile = nil
+	l.file = nil
+
+	backup := nextBackupName(path, time.Now())
+	if err := os.Rename(path, backup); err != nil {
+		// Keep logging to the original file even if the rename failed.
+		if openErr := l.openLogFile(path); openErr != nil {
+			return openErr
+		}
+		return fmt.Errorf("failed to rename log file %q: %w", path, err)
+	}
+	if l.checksumOnRotate {
+		l.bg.Add(1)
+		go func() {
+			defer l.bg.Done()
+			_ = writeChecksum(backup)
+		}()
+	}
+	if err := l.openLogFile(path); err != nil {
+		// Keep writing to the rotated file rather than losing entries; path is
+		// retried by the next rotation or reopen check (see SetReopenOnMissing).
+		if file, berr := openFile(backup); berr == nil {
+			l.logFile, l.file = file, file
+		}
+		return err
+	}
+	return nil
+}
+
+// backupName returns the rotated file name for path at time t.
+func backupName(path string, t time.Time) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	return base + "." + t.Format(backupTimeFormat) + ext
+}
+
+// nextBackupName returns the rotated file name for path at time t, moving t forward a
+// millisecond at a time while a backup of that name (compressed or not) exists, so two
+// rotations within the same millisecond do not overwrite each other.
+func nextBackupName(path string, t time.Time) string {
+	for {
+		name := backupName(path, t)
+		if !fileExists(name) && !fileExists(name+".gz") {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// fileExists reports whether a file exists at path.
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNextBackupNameUnique(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	now := time.Date(2025, 7, 28, 14, 47, 48, 0, time.UTC)
+	first := backupName(path, now)
+	if err := os.WriteFile(first, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(backupName(path, now.Add(time.Millisecond))+".gz", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := nextBackupName(path, now), backupName(path, now.Add(2*time.Millisecond)); got != want {
+		t.Errorf("nextBackupName = %q, want %q", got, want)
+	}
+}
+
+func TestRotateKeepsEveryBackup(t *testing.T) {
+	tests := []struct {
+		name   string
+		rotate func(t *testing.T, path string, contents []string)
+	}{
+		{"logger", func(t *testing.T, path string, contents []string) {
+			l := NewLogger(DISABLED, DEBUG)
+			defer l.Close()
+			if err := l.SetLogFile(path); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range contents {
+				l.Info("%s", c)
+				if err := l.Rotate(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}},
+		{"rotating file", func(t *testing.T, path string, contents []string) {
+			r, err := NewRotatingFile(path, RotateOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			for _, c := range contents {
+				if _, err := r.Write([]byte(c + "\n")); err != nil {
+					t.Fatal(err)
+				}
+				if err := r.Rotate(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.log")
+			contents := []string{"one", "two", "three", "four", "five"}
+			tt.rotate(t, path, contents)
+
+			backups, err := listBackups(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(backups) != len(contents) {
+				t.Fatalf("%d backups, want %d: %q", len(backups), len(contents), backups)
+			}
+			sort.Strings(backups)
+			for i, b := range backups {
+				data, err := os.ReadFile(b)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.HasSuffix(strings.TrimSpace(string(data)), contents[i]) {
+					t.Errorf("backup %s = %q, want %q", filepath.Base(b), data, contents[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotateOptions configures when a RotatingFile rotates and how backups are kept.
+type RotateOptions struct {
+	MaxSize    int64 // rotate before a write would exceed this many bytes; 0 disables size rotation
+	MaxBackups int   // number of rotated files to keep; 0 keeps all
+	Compress   bool  // gzip rotated files
+	Daily      bool  // rotate when the local date changes
+	Checksum   bool  // write a SHA-256 sidecar (".sha256") for each rotated file
+
+	// Policy, if set, replaces MaxSize and Daily in deciding when to rotate and names
+	// the rotated files. MaxBackups only prunes files with the default timestamp names.
+	Policy RotationPolicy
+}
+
+// RotationPolicy is a custom rotation rule for RotatingFile, e.g. hourly or per-deploy.
+//
+// ShouldRotate is called before every write with the current size of the file and the
+// time it was opened. NextFileName returns the path the current file is renamed to on
+// rotation, given the configured path (base); it must not return base itself.
+type RotationPolicy interface {
+	ShouldRotate(currentSize int64, openedAt time.Time) bool
+	NextFileName(base string) string
+}
+
+// RotatingFile is an io.WriteCloser writing to a file that is rotated by size and/or date.
+// Rotated files are renamed with a timestamp suffix next to the original
+// (e.g. "app.2025-07-28T14-47-48.000.log"). It can be used with SetFileWriter
+// or on its own with any library accepting an io.Writer.
+type RotatingFile struct {
+	path     string
+	opts     RotateOptions
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	wg       sync.WaitGroup // background compression and pruning
+	bgMu     sync.Mutex     // serializes background work
+	mu       sync.Mutex
+}
+
+// NewRotatingFile opens (or creates) the file at path for appending, creating directories if needed.
+func NewRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	r := &RotatingFile{path: path, opts: opts}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write implements io.Writer, rotating first if the write would exceed MaxSize
+// or the date changed since the file was opened.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if r.shouldRotate(int64(len(p)), time.Now()) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Rotate rotates the file immediately.
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return os.ErrClosed
+	}
+	return r.rotate()
+}
+
+// Sync commits the current file contents to stable storage.
+func (r *RotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return os.ErrClosed
+	}
+	return r.file.Sync()
+}
+
+// Close closes the file and waits for pending compression of rotated files.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	var err error
+	if r.file != nil {
+		err = r.file.Close()
+		r.file = nil
+	}
+	r.mu.Unlock()
+
+	r.wg.Wait()
+	return err
+}
+
+// shouldRotate reports whether writing n more bytes at now requires a rotation.
+// Must be called with r.mu held.
+func (r *RotatingFile) shouldRotate(n int64, now time.Time) bool {
+	if r.opts.Policy != nil {
+		return r.opts.Policy.ShouldRotate(r.size, r.openedAt)
+	}
+	if r.opts.MaxSize > 0 && r.size > 0 && r.size+n > r.opts.MaxSize {
+		return true
+	}
+	if r.opts.Daily {
+		y1, m1, d1 := r.openedAt.Date()
+		y2, m2, d2 := now.Date()
+		return y1 != y2 || m1 != m2 || d1 != d2
+	}
+	return false
+}
+
+// open opens the file at r.path.
+// Must be called with r.mu held (or before r is shared).
+func (r *RotatingFile) open() error {
+	file, err := openFile(r.path)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file %q: %w", r.path, err)
+	}
+	r.file = file
+	r.size = info.Size()
+	r.openedAt = time.Now()
+	return nil
+}
+
+// rotate renames the current file to a backup, opens a fresh one and
+// compresses/prunes backups in the background.
+// Must be called with r.mu held.
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file %q: %w", r.path, err)
+	}
+	r.file = nil
+
+	backup := nextBackupName(r.path, time.Now())
+	if r.opts.Policy != nil {
+		backup = r.opts.Policy.NextFileName(r.path)
+	}
+	renameErr := os.Rename(r.path, backup)
+	if err := r.open(); err != nil {
+		return err
+	}
+	if renameErr != nil {
+		return fmt.Errorf("failed to rename log file %q: %w", r.path, renameErr)
+	}
+
+	opts := r.opts
+	if opts.Compress || opts.Checksum || opts.MaxBackups > 0 {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			r.bgMu.Lock()
+			defer r.bgMu.Unlock()
+			final := backup
+			if opts.Compress && compressFile(backup) == nil {
+				final = backup + ".gz"
+			}
+			if opts.Checksum {
+				_ = writeChecksum(final)
+			}
+			if opts.MaxBackups > 0 {
+				_ = pruneBackups(r.path, opts.MaxBackups)
+			}
+		}()
+	}
+	return nil
+}
+
+// compressFile gzips path into path+".gz" and removes the original.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		zw.Close()
+		dst.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	src.Close()
+	return os.Remove(path)
+}
+
+// pruneBackups removes the oldest rotated files of path beyond keep.
+func pruneBackups(path string, keep int) error {
+	backups, err := listBackups(path)
+	if err != nil {
+		return err
+	}
+	if len(backups) <= keep {
+		return nil
+	}
+	for _, name := range backups[:len(backups)-keep] {
+		_ = os.Remove(name)
+		_ = os.Remove(name + checksumExt)
+	}
+	return nil
+}
+
+// listBackups returns the rotated files of path (compressed or not), oldest first.
+func listBackups(path string) ([]string, error) {
+	dir := filepath.Dir(path)
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(filepath.Base(path), ext) + "."
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimPrefix(name, prefix)
+		stamp = strings.TrimSuffix(stamp, ".gz")
+		stamp = strings.TrimSuffix(stamp, ext)
+		if _, err := time.Parse(backupTimeFormat, stamp); err != nil {
+			continue
+		}
+		backups = append(backups, filepath.Join(dir, name))
+	}
+	// The timestamp layout sorts lexically in chronological order.
+	sort.Slice(backups, func(i, j int) bool {
+		return strings.TrimSuffix(backups[i], ".gz") < strings.TrimSuffix(backups[j], ".gz")
+	})
+	return backups, nil
+}
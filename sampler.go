@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"sort"
+	"time"
+)
+
+// maxSamplerKeys bounds the number of distinct messages tracked by the sampler.
+// When exceeded, pending drop counts are reported and all counters are reset.
+const maxSamplerKeys = 4096
+
+// sampler keeps the first N occurrences of a message and every Mth after that.
+type sampler struct {
+	first      int
+	thereafter int
+	counts     map[string]*sampleCount
+}
+
+// sampleCount tracks occurrences of a single message.
+type sampleCount struct {
+	seen    int
+	dropped int
+	level   LogLevel // of the latest occurrence
+}
+
+// SetSampler enables sampling keyed on the formatted message: the first `first`
+// occurrences of each message are logged, then only every `thereafter`-th one.
+// Sampled entries carry a "dropped" field with the number of suppressed duplicates
+// since the previous one. A thereafter value <= 0 drops everything after the first entries.
+// Duplicates dropped since the last kept entry are reported on Flush and Close, when
+// sampling is reconfigured and when the tracked messages are reset, as a "sampling
+// dropped entries" entry at the message's level with sampled_message and dropped fields.
+// Calling SetSampler with first <= 0 and thereafter <= 0 disables sampling.
+func (l *Logger) SetSampler(first, thereafter int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reportSampled()
+	if first <= 0 && thereafter <= 0 {
+		l.sampler = nil
+		return
+	}
+	l.sampler = &sampler{first: first, thereafter: thereafter, counts: make(map[string]*sampleCount)}
+}
+
+// full reports whether tracking message would exceed maxSamplerKeys.
+func (s *sampler) full(message string) bool {
+	return len(s.counts) >= maxSamplerKeys && s.counts[message] == nil
+}
+
+// check reports whether message should be logged and how many occurrences were dropped before it.
+func (s *sampler) check(level LogLevel, message string) (bool, int) {
+	c := s.counts[message]
+	if c == nil {
+		c = &sampleCount{}
+		s.counts[message] = c
+	}
+	c.seen++
+	c.level = level
+
+	if c.seen <= s.first {
+		return true, 0
+	}
+	if s.thereafter > 0 && (c.seen-s.first)%s.thereafter == 0 {
+		dropped := c.dropped
+		c.dropped = 0
+		return true, dropped
+	}
+	c.dropped++
+	return false, 0
+}
+
+// reportSampled logs the duplicates dropped since the last kept entry of each message,
+// sorted by message, and clears the drop counts.
+// Must be called with l.mu held.
+func (l *Logger) reportSampled() {
+	if l.sampler == nil {
+		return
+	}
+	var messages []string
+	for message, c := range l.sampler.counts {
+		if c.dropped > 0 {
+			messages = append(messages, message)
+		}
+	}
+	sort.Strings(messages)
+	t := time.Now()
+	for _, message := range messages {
+		c := l.sampler.counts[message]
+		l.emit(t, c.level, "sampling dropped entries", []Field{Str("sampled_message", message), Int("dropped", c.dropped)})
+		c.dropped = 0
+	}
+}
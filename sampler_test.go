@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSamplerReportsDrops(t *testing.T) {
+	tests := []struct {
+		name              string
+		first, thereafter int
+		calls             int
+		want              []string
+	}{
+		{"drop all after first", 2, 0, 5, []string{
+			"hot", "hot",
+			"sampling dropped entries dropped=3 sampled_message=hot",
+		}},
+		{"every third", 1, 3, 6, []string{
+			"hot", "hot dropped=2",
+			"sampling dropped entries dropped=2 sampled_message=hot",
+		}},
+		{"nothing dropped", 5, 0, 3, []string{"hot", "hot", "hot"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console := newTestLogger(t)
+			l.SetSampler(tt.first, tt.thereafter)
+			for i := 0; i < tt.calls; i++ {
+				l.Info("hot")
+			}
+			_ = l.Flush()
+			// Counts are reported once.
+			_ = l.Flush()
+			if got := textMessages(console.String()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSamplerReportsOnClose(t *testing.T) {
+	l, console := newTestLogger(t)
+	l.SetSampler(1, 0)
+	l.Fail("hot")
+	l.Fail("hot")
+	l.Close()
+	want := []string{"hot", "sampling dropped entries dropped=1 sampled_message=hot"}
+	if got := textMessages(console.String()); !reflect.DeepEqual(got, want) {
+		t.Errorf("messages = %q, want %q", got, want)
+	}
+}
+
+func TestSamplerReportsOnReset(t *testing.T) {
+	l, console := newTestLogger(t)
+	l.SetSampler(1, 0)
+	l.Info("hot")
+	l.Info("hot")
+	l.mu.Lock()
+	for i := 0; len(l.sampler.counts) < maxSamplerKeys; i++ {
+		l.sampler.counts[string(rune('a'+i%26))+string(rune(i))] = &sampleCount{seen: 1}
+	}
+	l.mu.Unlock()
+	console.Reset()
+	l.Info("new")
+	want := []string{"sampling dropped entries dropped=1 sampled_message=hot", "new"}
+	if got := textMessages(console.String()); !reflect.DeepEqual(got, want) {
+		t.Errorf("messages = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+package logger
+
+// SetSanitizeControlChars enables or disables escaping of control characters in text
+// messages for both console and file output. When enabled, characters such as '\r', '\n'
+// and ESC are written as "\r", "\n" and "\x1b", so untrusted input cannot forge log lines
+// or inject terminal escape sequences. Tabs are kept. By default sanitizing is on for the
+// console and off for the file. JSON output is always escaped. With SetIndentMultiline,
+// newlines are kept and the continuation lines indented instead.
+func (l *Logger) SetSanitizeControlChars(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sanitizeConsole = enabled
+	l.sanitizeFile = enabled
+}
+
+// sanitizeMode selects how control characters in text messages are written.
+type sanitizeMode uint8
+
+const (
+	sanitizeOff          sanitizeMode = iota // written as they are
+	sanitizeEscape                           // escaped, see SetSanitizeControlChars
+	sanitizeKeepNewlines                     // escaped except '\n', for indented entries
+)
+
+// appendSanitized appends s to buf with ASCII control characters (except tab, and '\n'
+// with keepNewlines) escaped.
+func appendSanitized(buf []byte, s string, keepNewlines bool) []byte {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\n' && keepNewlines:
+			buf = append(buf, c)
+		case c == '\n':
+			buf = append(buf, '\\', 'n')
+		case c == '\r':
+			buf = append(buf, '\\', 'r')
+		case c == '\t':
+			buf = append(buf, c)
+		case c < 0x20 || c == 0x7f:
+			buf = append(buf, '\\', 'x', hexDigits[c>>4], hexDigits[c&0xf])
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return buf
+}
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeConsole(t *testing.T) {
+	const evil = "x\x1b[2J\ry"
+	tests := []struct {
+		name string
+		log  func(l *Logger)
+	}{
+		{"message", func(l *Logger) { l.Info("%s", evil) }},
+		{"field", func(l *Logger) { l.Event(INFO, "msg", Str("k", evil)) }},
+		{"table cell", func(l *Logger) { l.Table(INFO, []map[string]interface{}{{"col": evil}}) }},
+		{"table column", func(l *Logger) { l.Table(INFO, []map[string]interface{}{{evil: 1}}) }},
+		{"diff line", func(l *Logger) { l.Diff(INFO, "config", "a\n", evil+"\n") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console := newTestLogger(t)
+			tt.log(l)
+			out := console.String()
+			if strings.ContainsAny(out, "\x1b\r") {
+				t.Errorf("raw control characters on the console: %q", out)
+			}
+		})
+	}
+}
+
+func TestSanitizeTableDisabled(t *testing.T) {
+	l, console := newTestLogger(t)
+	l.SetSanitizeControlChars(false)
+	l.Table(INFO, []map[string]interface{}{{"col": "a\nb"}})
+	if out := console.String(); !strings.Contains(out, "| a b |") {
+		t.Errorf("unsanitized cell newline not flattened: %q", out)
+	}
+}
+
+func TestProgressBarLabel(t *testing.T) {
+	tests := []struct {
+		sanitize bool
+		want     string
+	}{
+		{true, "\rup\\x1b[2J\\rload ["},
+		{false, "\rup\x1b[2J\rload ["},
+	}
+	for _, tt := range tests {
+		got := string(appendProgressBar(nil, 1, 2, 50, "up\x1b[2J\rload", tt.sanitize))
+		if !strings.HasPrefix(got, tt.want) {
+			t.Errorf("sanitize=%v: got %q, want prefix %q", tt.sanitize, got, tt.want)
+		}
+	}
+}
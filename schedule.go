@@ -0,0 +1,30 @@
+package logger
+
+import "time"
+
+// scheduleInterval is how often the level schedule is consulted.
+const scheduleInterval = time.Minute
+
+// SetLevelSchedule makes fn decide the console and file level over time, e.g. DEBUG during
+// business hours and INFO otherwise. fn is called with the current time, also for entries
+// backdated with LogAt or Replay, at most once per minute and its result replaces both
+// levels; outputs that are DISABLED stay disabled. Context override levels (see WithLevel)
+// still take precedence. A nil fn restores the static levels.
+func (l *Logger) SetLevelSchedule(fn func(time.Time) LogLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levelSchedule = fn
+	l.scheduleNext = time.Time{}
+}
+
+// scheduledLevel returns the level chosen by the schedule for the current time t,
+// consulting it if the cached level has expired.
+// Must be called with l.mu held.
+func (l *Logger) scheduledLevel(t time.Time) LogLevel {
+	if t.Before(l.scheduleNext) && !t.Before(l.scheduleNext.Add(-scheduleInterval)) {
+		return l.scheduleLevel
+	}
+	l.scheduleLevel = l.levelSchedule(t)
+	l.scheduleNext = t.Truncate(scheduleInterval).Add(scheduleInterval)
+	return l.scheduleLevel
+}
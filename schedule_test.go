@@ -0,0 +1,24 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLevelScheduleUsesCurrentTime(t *testing.T) {
+	l, console := newTestLogger(t)
+	cutoff := time.Now().Add(-time.Hour)
+	l.SetLevelSchedule(func(t time.Time) LogLevel {
+		if t.Before(cutoff) {
+			return DEBUG
+		}
+		return ERROR
+	})
+	l.LogAt(cutoff.Add(-time.Hour), INFO, "backdated")
+	l.LogAt(cutoff.Add(-time.Hour), ERROR, "backdated error")
+
+	got := textMessages(console.String())
+	if len(got) != 1 || got[0] != "backdated error" {
+		t.Errorf("got %q, want only the entry passing the current schedule", got)
+	}
+}
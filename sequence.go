@@ -0,0 +1,11 @@
+package logger
+
+// SetIncludeSequence enables a monotonically increasing sequence number on every entry
+// ("#42 | message" in text, a "seq" field in JSON), useful for detecting dropped
+// or reordered lines in downstream pipelines. Numbering starts at 1 and is shared
+// by all outputs and child loggers.
+func (l *Logger) SetIncludeSequence(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.includeSequence = enabled
+}
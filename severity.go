@@ -0,0 +1,38 @@
+package logger
+
+// SetSeverity overrides the severity used when comparing level against output thresholds.
+// By default a level's severity is its numeric value:
+//
+//	DEBUG=0, INFO=1, SUCCESS=2, FAIL=3, ERROR=4
+//
+// A message is written when its severity is at least the severity of the output's level.
+// For example, SetSeverity(SUCCESS, 0) makes SUCCESS messages visible only when DEBUG is enabled.
+// DISABLED always disables output and cannot be remapped.
+func (l *Logger) SetSeverity(level LogLevel, severity int) {
+	if level == DISABLED {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.severities == nil {
+		l.severities = make(map[LogLevel]int)
+	}
+	l.severities[level] = severity
+}
+
+// severity returns the configured severity of level.
+// Must be called with l.mu held.
+func (l *Logger) severity(level LogLevel) int {
+	if s, ok := l.severities[level]; ok {
+		return s
+	}
+	return int(level)
+}
+
+// SetNumericLevels adds the level's severity number (see SetSeverity) next to its name:
+// "| ERROR(4) |" in text output and a "severity_number" field in JSON.
+func (l *Logger) SetNumericLevels(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.numericLevels = enabled
+}
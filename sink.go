@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"errors"
+	"time"
+)
+
+// Sink is an additional destination receiving rendered entries, e.g. a log shipping
+// service. Each Write call carries exactly one entry, formatted in the sink's Format
+// and terminated by a newline, along with its timestamp and level. Writes happen with
+// the logger locked, so implementations should queue slow work rather than block.
+// The entry slice is reused by the logger once Write returns: a sink that keeps the
+// data (e.g. to send it later) must copy it.
+// Sinks are compared by identity (see RemoveSink) and should be pointer types.
+type Sink interface {
+	Write(t time.Time, level LogLevel, entry []byte) error
+	Close() error
+}
+
+// sinkOutput is a sink attached with AddSink.
+type sinkOutput struct {
+	sink     Sink
+	level    LogLevel
+	maxLevel LogLevel // DISABLED for no upper bound
+	format   Format
+}
+
+// AddSink attaches s as an additional destination for entries at level and above,
+// rendered in format f. Sinks are closed by Close.
+//
+// Each entry is captured once and rendered separately for every output, so the console,
+// the log file and each sink describe the same event with identical fields whatever their
+// formats. Attaching the same sink again with another format makes it receive every
+// entry once per format, e.g. a readable text line followed by its JSON form.
+func (l *Logger) AddSink(s Sink, level LogLevel, f Format) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sinks = append(l.sinks, &sinkOutput{sink: s, level: level, maxLevel: DISABLED, format: f})
+}
+
+// AddSinkRange is like AddSink but only passes entries from minLevel up to and including
+// maxLevel, e.g. SUCCESS and FAIL without ERROR. Audit entries are passed
+// regardless of the range.
+func (l *Logger) AddSinkRange(s Sink, minLevel, maxLevel LogLevel, f Format) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sinks = append(l.sinks, &sinkOutput{sink: s, level: minLevel, maxLevel: maxLevel, format: f})
+}
+
+// RemoveSink detaches every attachment of s without closing it.
+func (l *Logger) RemoveSink(s Sink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	kept := make([]*sinkOutput, 0, len(l.sinks))
+	for _, so := range l.sinks {
+		if so.sink != s {
+			kept = append(kept, so)
+		}
+	}
+	l.sinks = kept
+}
+
+// writeSinks renders e for every sink whose level range it falls in and reports whether
+// any sink took it. Write errors are ignored, except that a sink disabled by a circuit
+// breaker is reported on the other outputs.
+// Must be called with l.mu held.
+func (l *Logger) writeSinks(buf *[]byte, e *entry) bool {
+	var disabled []error
+	written := false
+	for _, so := range l.sinks {
+		level := so.level
+		if e.hasMinLevel {
+			level = overrideLevel(level, e.minLevel)
+		}
+		if !e.audit && (!l.shouldLog(e.level, level) || !l.belowMax(e.level, so.maxLevel)) {
+			continue
+		}
+		*buf = l.format((*buf)[:0], e, so.format, false)
+		written = true
+		if err := so.sink.Write(e.time, e.level, *buf); errors.Is(err, ErrSinkDisabled) {
+			disabled = append(disabled, err)
+		}
+	}
+	for _, err := range disabled {
+		l.emit(time.Now(), FAIL, err.Error(), nil)
+	}
+	return written
+}
+
+// closeSinks closes and detaches all sinks, closing a sink attached several times once.
+// Must be called with l.mu held.
+func (l *Logger) closeSinks() {
+	closed := make(map[Sink]bool, len(l.sinks))
+	for _, so := range l.sinks {
+		if !closed[so.sink] {
+			closed[so.sink] = true
+			_ = so.sink.Close()
+		}
+	}
+	l.sinks = nil
+}
+
+// belowMax reports whether msgLevel is at or below maxLevel; DISABLED means no bound.
+// Must be called with l.mu held.
+func (l *Logger) belowMax(msgLevel, maxLevel LogLevel) bool {
+	return maxLevel == DISABLED || l.severity(msgLevel) <= l.severity(maxLevel)
+}
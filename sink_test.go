@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordSink keeps the entries written to it.
+type recordSink struct {
+	entries []string
+	closed  int
+}
+
+func (s *recordSink) Write(_ time.Time, _ LogLevel, entry []byte) error {
+	s.entries = append(s.entries, string(entry))
+	return nil
+}
+
+func (s *recordSink) Close() error {
+	s.closed++
+	return nil
+}
+
+func TestSinkSeveralFormats(t *testing.T) {
+	l, _ := newTestLogger(t)
+	s := &recordSink{}
+	l.AddSink(s, DEBUG, FormatText)
+	l.AddSink(s, DEBUG, FormatJSON)
+	l.Event(INFO, "saved", Str("user", "alice"), Int("n", 3))
+
+	if len(s.entries) != 2 {
+		t.Fatalf("sink got %d entries, want one per format: %q", len(s.entries), s.entries)
+	}
+	checkEntry(t, "text", FormatText, s.entries[0])
+	checkEntry(t, "json", FormatJSON, s.entries[1])
+
+	l.RemoveSink(s)
+	l.Info("after remove")
+	if len(s.entries) != 2 {
+		t.Errorf("removed sink got %q", s.entries[2:])
+	}
+}
+
+func TestSinkClosedOnce(t *testing.T) {
+	l := NewLogger(DEBUG, DISABLED)
+	l.SetConsoleWriter(nil)
+	s := &recordSink{}
+	l.AddSink(s, DEBUG, FormatText)
+	l.AddSink(s, DEBUG, FormatJSON)
+	l.Close()
+	if s.closed != 1 {
+		t.Errorf("sink closed %d times, want 1", s.closed)
+	}
+}
+
+// TestSameEventAcrossFormats checks that the console text and the file JSON describe the
+// same event: the same time, level, message and fields.
+func TestSameEventAcrossFormats(t *testing.T) {
+	l, console, file := newDualLogger(t, FormatText, FormatJSON)
+	l.SetTimePrecision(Micros)
+	l.WithFields(Str("req", "r1")).Event(FAIL, "rejected", Str("reason", "quota exceeded"), Bool("retry", false))
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(file.Bytes(), &got); err != nil {
+		t.Fatalf("file: %v: %q", err, file.String())
+	}
+	ts, err := time.Parse(time.RFC3339Nano, got["time"].(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ts.Local().Format(Micros.timeLayout()) + ` | FAIL | rejected reason="quota exceeded" req=r1 retry=false` + "\n"
+	if console.String() != want {
+		t.Errorf("console = %q, want %q", console.String(), want)
+	}
+	for k, v := range map[string]interface{}{"level": "FAIL", "message": "rejected", "req": "r1", "reason": "quota exceeded", "retry": false} {
+		if got[k] != v {
+			t.Errorf("file %s = %v, want %v", k, got[k], v)
+		}
+	}
+	if strings.Count(file.String(), "\n") != 1 {
+		t.Errorf("file has more than one entry: %q", file.String())
+	}
+}
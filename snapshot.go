@@ -0,0 +1,334 @@
+package logger
+
+import (
+	"io"
+	"time"
+)
+
+// Config is a snapshot of a logger's settings taken by Snapshot.
+// Writers are kept by reference; a log file opened by the logger is kept by path
+// and reopened on restore if it was replaced in the meantime.
+type Config struct {
+	consoleLevel     LogLevel
+	fileLevel        LogLevel
+	levelSchedule    func(time.Time) LogLevel
+	console          io.Writer
+	consoleQueue     int // SetConsoleNonBlocking buffer size, 0 for blocking writes
+	stderrLevel      LogLevel
+	orderedStreams   bool
+	fallbackStderr   bool
+	file             io.Writer
+	logPath          string
+	fileMode         LogFileMode
+	defaultDir       string
+	defaultFileName  string
+	checksumOnRotate bool
+	flushEveryN      int
+	durability       DurabilityPolicy
+	reopenOnMissing  bool
+	eventInterval    time.Duration
+	memStatsInterval time.Duration
+	groupIdle        time.Duration
+	callerMode       CallerMode
+	includePackage   bool
+	stackLevel       LogLevel
+	includeSequence  bool
+	timePrecision    TimePrecision
+	relativeTime     bool
+	monotonic        bool
+	sanitizeConsole  bool
+	sanitizeFile     bool
+	consoleFormat    Format
+	prettyJSON       bool
+	schemaVersion    int
+	gcpProject       string
+	fileFormat       Format
+	lineEnding       string
+	sampler          *sampler
+	repanic          bool
+	summaryOnClose   bool
+	severities       map[LogLevel]int
+	numericLevels    bool
+	levelAlign       Alignment
+	levelWidth       int
+	indentMultiline  bool
+	levelColors      map[LogLevel]string
+	colorMode        colorMode
+	redactKeys       map[string]bool
+	fieldAllowlist   map[string]bool
+	httpBodyLimit    int
+	httpPanicDetails bool
+	tableRowLimit    int
+	diffLineLimit    int
+	sqlQueryLimit    int
+	sqlRedactArgs    bool
+	autoData         bool
+	pauseSize        int
+	strictFormat     bool
+	gaugeHook        func(name string, value float64)
+	classifiers      []func(error) map[string]interface{}
+	closeHooks       []func()
+	levelHooks       []func(console, file LogLevel)
+	crashPath        string
+	crashEntries     int
+	version          string
+	buildFields      []Field
+	envPrefixes      []string
+}
+
+// Snapshot captures the current settings of l: levels, writers, formats, colors, hooks,
+// the crash log and all other options, e.g. so a test can reconfigure the logger and put
+// it back with RestoreSnapshot. Outputs the logger opens itself are not included: sinks,
+// per-level files, the event log, journald, and the Unix socket and network outputs are
+// left as they are on restore. Entries held in the crash log are not restored.
+func (l *Logger) Snapshot() Config {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c := Config{
+		consoleLevel:     l.consoleLevel,
+		fileLevel:        l.fileLevel,
+		levelSchedule:    l.levelSchedule,
+		console:          l.console,
+		stderrLevel:      l.stderrLevel,
+		orderedStreams:   l.orderedStreams,
+		fallbackStderr:   l.fallbackStderr,
+		file:             l.file,
+		logPath:          l.logPath,
+		fileMode:         l.fileMode,
+		defaultDir:       l.defaultDir,
+		defaultFileName:  l.defaultFileName,
+		checksumOnRotate: l.checksumOnRotate,
+		flushEveryN:      l.flushEveryN,
+		durability:       l.durability,
+		reopenOnMissing:  l.reopenOnMissing,
+		eventInterval:    l.eventInterval,
+		memStatsInterval: l.memStatsInterval,
+		groupIdle:        l.groupIdle,
+		callerMode:       l.callerMode,
+		includePackage:   l.includePackage,
+		stackLevel:       l.stackLevel,
+		includeSequence:  l.includeSequence,
+		timePrecision:    l.timePrecision,
+		relativeTime:     l.relativeTime,
+		monotonic:        l.monotonic,
+		sanitizeConsole:  l.sanitizeConsole,
+		sanitizeFile:     l.sanitizeFile,
+		consoleFormat:    l.consoleFormat,
+		prettyJSON:       l.prettyJSON,
+		schemaVersion:    l.schemaVersion,
+		gcpProject:       l.gcpProject,
+		fileFormat:       l.fileFormat,
+		lineEnding:       l.lineEnding,
+		sampler:          l.sampler,
+		repanic:          l.repanic,
+		summaryOnClose:   l.summaryOnClose,
+		numericLevels:    l.numericLevels,
+		colorMode:        l.colorMode,
+		levelAlign:       l.levelAlign,
+		levelWidth:       l.levelWidth,
+		indentMultiline:  l.indentMultiline,
+		httpBodyLimit:    l.httpBodyLimit,
+		httpPanicDetails: l.httpPanicDetails,
+		tableRowLimit:    l.tableRowLimit,
+		diffLineLimit:    l.diffLineLimit,
+		sqlQueryLimit:    l.sqlQueryLimit,
+		sqlRedactArgs:    l.sqlRedactArgs,
+		autoData:         l.autoData,
+		pauseSize:        l.pauseSize,
+		strictFormat:     l.strictFormat,
+		gaugeHook:        l.gaugeHook,
+		classifiers:      append([]func(error) map[string]interface{}(nil), l.classifiers...),
+		closeHooks:       append(([]func())(nil), l.closeHooks...),
+		levelHooks:       append(([]func(console, file LogLevel))(nil), l.levelHooks...),
+		version:          l.version,
+		buildFields:      l.buildFields,
+		envPrefixes:      append([]string(nil), l.envPrefixes...),
+		severities:       copySeverities(l.severities),
+		levelColors:      copyLevelColors(l.levelColors),
+		redactKeys:       copyKeySet(l.redactKeys),
+		fieldAllowlist:   copyKeySet(l.fieldAllowlist),
+	}
+	if l.consoleAsync != nil {
+		c.console = l.consoleAsync.w
+		c.consoleQueue = cap(l.consoleAsync.ch)
+	}
+	if l.crash != nil {
+		c.crashPath, c.crashEntries = l.crash.path, len(l.crash.entries)
+	}
+	return c
+}
+
+// RestoreSnapshot puts back the settings captured by Snapshot. A log file that has been
+// replaced since the snapshot is reopened by path; if that fails, the error is returned
+// and file output is left unchanged.
+func (l *Logger) RestoreSnapshot(c Config) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.restoreFile(c); err != nil {
+		return err
+	}
+
+	if l.consoleAsync != nil {
+		l.consoleAsync.close()
+		l.consoleAsync = nil
+	}
+	l.console = c.console
+	if c.consoleQueue > 0 && c.console != nil {
+		l.consoleAsync = newAsyncWriter(c.console, c.consoleQueue)
+		l.console = l.consoleAsync
+	}
+
+	if l.durability != c.durability {
+		l.stopSync()
+		l.durability = c.durability
+		if c.durability.mode == syncInterval {
+			l.syncStop = make(chan struct{})
+			go l.syncLoop(c.durability.interval, l.syncStop)
+		}
+	}
+
+	l.consoleLevel = c.consoleLevel
+	l.stderrLevel = c.stderrLevel
+	l.orderedStreams = c.orderedStreams
+	l.fallbackStderr = c.fallbackStderr
+	l.fileLevel = c.fileLevel
+	l.levelSchedule = c.levelSchedule
+	l.scheduleNext = time.Time{}
+	l.fileMode = c.fileMode
+	l.defaultDir = c.defaultDir
+	l.defaultFileName = c.defaultFileName
+	l.checksumOnRotate = c.checksumOnRotate
+	l.flushEveryN = c.flushEveryN
+	l.reopenOnMissing = c.reopenOnMissing
+	l.setEventInterval(c.eventInterval)
+	l.setMemStatsInterval(c.memStatsInterval)
+	l.setGroupIdle(c.groupIdle)
+	l.callerMode = c.callerMode
+	l.includePackage = c.includePackage
+	l.stackLevel = c.stackLevel
+	l.includeSequence = c.includeSequence
+	l.timePrecision = c.timePrecision
+	l.relativeTime = c.relativeTime
+	l.monotonic = c.monotonic
+	l.sanitizeConsole = c.sanitizeConsole
+	l.sanitizeFile = c.sanitizeFile
+	l.consoleFormat = c.consoleFormat
+	l.prettyJSON = c.prettyJSON
+	l.schemaVersion = c.schemaVersion
+	l.gcpProject = c.gcpProject
+	l.fileFormat = c.fileFormat
+	l.lineEnding = c.lineEnding
+	if l.sampler != c.sampler {
+		l.reportSampled()
+	}
+	l.sampler = c.sampler
+	l.repanic = c.repanic
+	l.summaryOnClose = c.summaryOnClose
+	l.severities = copySeverities(c.severities)
+	l.numericLevels = c.numericLevels
+	l.levelAlign = c.levelAlign
+	l.levelWidth = c.levelWidth
+	l.indentMultiline = c.indentMultiline
+	l.levelColors = copyLevelColors(c.levelColors)
+	l.colorMode = c.colorMode
+	l.redactKeys = copyKeySet(c.redactKeys)
+	l.fieldAllowlist = copyKeySet(c.fieldAllowlist)
+	l.httpBodyLimit = c.httpBodyLimit
+	l.httpPanicDetails = c.httpPanicDetails
+	l.tableRowLimit = c.tableRowLimit
+	l.diffLineLimit = c.diffLineLimit
+	l.sqlQueryLimit = c.sqlQueryLimit
+	l.sqlRedactArgs = c.sqlRedactArgs
+	l.autoData = c.autoData
+	l.pauseSize = c.pauseSize
+	l.strictFormat = c.strictFormat
+	l.gaugeHook = c.gaugeHook
+	l.classifiers = append([]func(error) map[string]interface{}(nil), c.classifiers...)
+	l.closeHooks = append(([]func())(nil), c.closeHooks...)
+	l.levelHooks = append(([]func(console, file LogLevel))(nil), c.levelHooks...)
+	l.restoreCrashLog(c)
+	l.version = c.version
+	l.buildFields = c.buildFields
+	l.envPrefixes = append([]string(nil), c.envPrefixes...)
+	return nil
+}
+
+// restoreFile puts back the file destination of c.
+// Must be called with l.mu held.
+func (l *Logger) restoreFile(c Config) error {
+	if c.logPath != "" {
+		if c.logPath == l.logPath {
+			return nil
+		}
+		prev := l.logFile
+		if err := l.openLogFile(c.logPath); err != nil {
+			return err
+		}
+		if prev != nil {
+			prev.Close()
+		}
+		return nil
+	}
+
+	_ = l.flushFile()
+	if l.logFile != nil && c.file != io.Writer(l.logFile) {
+		l.logFile.Close()
+	}
+	l.logFile = nil
+	l.logPath = ""
+	l.file = c.file
+	return nil
+}
+
+// restoreCrashLog puts back the crash log of c, keeping the recorded entries if its
+// path and size are unchanged.
+// Must be called with l.mu held.
+func (l *Logger) restoreCrashLog(c Config) {
+	if c.crashPath == "" {
+		l.crash = nil
+		return
+	}
+	if l.crash != nil && l.crash.path == c.crashPath && len(l.crash.entries) == c.crashEntries {
+		return
+	}
+	l.crash = &crashLog{path: c.crashPath, entries: make([][]byte, c.crashEntries)}
+}
+
+// copySeverities returns a copy of m. Snapshot maps are copied in both directions since
+// setters such as SetLevelColor update them in place.
+func copySeverities(m map[LogLevel]int) map[LogLevel]int {
+	if m == nil {
+		return nil
+	}
+	c := make(map[LogLevel]int, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// copyLevelColors returns a copy of m.
+func copyLevelColors(m map[LogLevel]string) map[LogLevel]string {
+	if m == nil {
+		return nil
+	}
+	c := make(map[LogLevel]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// copyKeySet returns a copy of m.
+func copyKeySet(m map[string]bool) map[string]bool {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]bool, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
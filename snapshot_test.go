@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSnapshotRestoresCrashLogAndHooks(t *testing.T) {
+	l, _ := newTestLogger(t)
+	path := filepath.Join(t.TempDir(), "crash.log")
+	l.SetCrashLog(path, 5)
+	var levelCalls, closeCalls []string
+	l.OnLevelChange(func(console, file LogLevel) { levelCalls = append(levelCalls, "kept") })
+	l.OnClose(func() { closeCalls = append(closeCalls, "kept") })
+	snap := l.Snapshot()
+
+	l.SetCrashLog("", 0)
+	l.OnLevelChange(func(console, file LogLevel) { levelCalls = append(levelCalls, "added") })
+	l.OnClose(func() { closeCalls = append(closeCalls, "added") })
+	if err := l.RestoreSnapshot(snap); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.crash == nil || l.crash.path != path || len(l.crash.entries) != 5 {
+		t.Errorf("crash log not restored: %+v", l.crash)
+	}
+	l.SetConsoleLevel(ERROR)
+	l.Close()
+	if len(levelCalls) != 1 || levelCalls[0] != "kept" {
+		t.Errorf("level hooks = %q, want [kept]", levelCalls)
+	}
+	if len(closeCalls) != 1 || closeCalls[0] != "kept" {
+		t.Errorf("close hooks = %q, want [kept]", closeCalls)
+	}
+}
+
+func TestSnapshotKeepsCrashEntries(t *testing.T) {
+	l, _ := newTestLogger(t)
+	l.SetCrashLog(filepath.Join(t.TempDir(), "crash.log"), 5)
+	snap := l.Snapshot()
+	l.Info("recorded")
+	if err := l.RestoreSnapshot(snap); err != nil {
+		t.Fatal(err)
+	}
+	if l.crash.next != 1 {
+		t.Errorf("unchanged crash log was reset, next = %d", l.crash.next)
+	}
+}
@@ -0,0 +1,204 @@
+package logger
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// socketWriteTimeout bounds a single write to a socket output.
+	socketWriteTimeout = time.Second
+	// socketRedialInterval is the minimum time between reconnection attempts.
+	socketRedialInterval = time.Second
+	// maxSocketBacklog is the maximum number of bytes held while disconnected.
+	maxSocketBacklog = 1 << 20
+)
+
+// socketOutput writes entries to a socket, reconnecting after failures.
+// Reconnection happens in the background so a slow endpoint does not stall logging.
+type socketOutput struct {
+	addr       string
+	networks   []string // candidate networks, tried in order
+	network    string   // network of the established connection
+	conn       net.Conn
+	lastDial   time.Time
+	dialing    bool
+	dialed     chan net.Conn // result of a background dial, nil on failure
+	backlog    [][]byte      // entries written while disconnected, oldest first
+	backlogLen int
+}
+
+// newSocketOutput connects to addr over the first of networks that accepts the connection.
+func newSocketOutput(addr string, networks ...string) (*socketOutput, error) {
+	s := &socketOutput{addr: addr, networks: networks, dialed: make(chan net.Conn, 1)}
+	s.lastDial = time.Now()
+	conn, network, err := s.dial()
+	if err != nil {
+		return nil, err
+	}
+	s.conn, s.network = conn, network
+	return s, nil
+}
+
+// SetUnixSocketOutput writes every entry that goes to the log file (same level and
+// format) also to the Unix domain socket at path, e.g. a local Vector or Fluent Bit
+// forwarder or /dev/log. Stream and datagram sockets are supported; with a datagram
+// socket each entry is sent as one datagram. If the connection fails, entries are held
+// (up to 1 MiB, newer entries are dropped beyond that) and the socket is redialed at
+// most once per second. An empty path removes the output.
+func (l *Logger) SetUnixSocketOutput(path string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if path == "" {
+		if l.socket != nil {
+			l.socket.close()
+			l.socket = nil
+		}
+		return nil
+	}
+
+	s, err := newSocketOutput(path, "unix", "unixgram")
+	if err != nil {
+		return err
+	}
+	if l.socket != nil {
+		l.socket.close()
+	}
+	l.socket = s
+	return nil
+}
+
+// SetNetworkOutput writes every entry that goes to the log file (same level and format)
+// also to a remote collector such as Logstash or Fluentd. The network is "tcp" or "udp"
+// (or their "4"/"6" variants) and addr is "host:port". Over TCP, entries are held while
+// the connection is down (up to 1 MiB) and the collector is redialed in the background
+// at most once per second; over UDP each entry is sent as one datagram and failed sends
+// are dropped. Writes time out after a second. An empty addr removes the output.
+func (l *Logger) SetNetworkOutput(network, addr string) error {
+	if addr != "" && !strings.HasPrefix(network, "tcp") && !strings.HasPrefix(network, "udp") {
+		return fmt.Errorf("unsupported network %q, want tcp or udp", network)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if addr == "" {
+		if l.netOutput != nil {
+			l.netOutput.close()
+			l.netOutput = nil
+		}
+		return nil
+	}
+
+	s, err := newSocketOutput(addr, network)
+	if err != nil {
+		return err
+	}
+	if l.netOutput != nil {
+		l.netOutput.close()
+	}
+	l.netOutput = s
+	return nil
+}
+
+// dial connects over the first network that accepts the connection.
+func (s *socketOutput) dial() (net.Conn, string, error) {
+	var err error
+	for _, network := range s.networks {
+		var conn net.Conn
+		conn, err = net.DialTimeout(network, s.addr, socketWriteTimeout)
+		if err == nil {
+			return conn, network, nil
+		}
+	}
+	return nil, "", fmt.Errorf("failed to connect to %q: %w", s.addr, err)
+}
+
+// redial picks up the result of a background dial, or starts one if the redial
+// interval has passed.
+func (s *socketOutput) redial(now time.Time) {
+	if s.dialing {
+		select {
+		case conn := <-s.dialed:
+			s.dialing = false
+			s.conn = conn
+		default:
+		}
+		return
+	}
+	if now.Sub(s.lastDial) < socketRedialInterval {
+		return
+	}
+	s.lastDial = now
+	s.dialing = true
+	if s.network != "" {
+		// Keep the kind of socket the output was first connected with.
+		s.networks = []string{s.network}
+	}
+	go func() {
+		conn, _, err := s.dial()
+		if err != nil {
+			conn = nil
+		}
+		s.dialed <- conn
+	}()
+}
+
+// datagram reports whether the output sends entries as fire-and-forget datagrams.
+func (s *socketOutput) datagram() bool {
+	return strings.HasPrefix(s.network, "udp")
+}
+
+// write sends p, first sending held entries after a reconnect. On failure p is held,
+// except on UDP where it is dropped.
+func (s *socketOutput) write(p []byte) {
+	now := time.Now()
+	if s.conn == nil {
+		s.redial(now)
+	}
+	if s.conn != nil {
+		for len(s.backlog) > 0 && s.send(now, s.backlog[0]) {
+			s.backlogLen -= len(s.backlog[0])
+			s.backlog = s.backlog[1:]
+		}
+		if len(s.backlog) == 0 && s.send(now, p) {
+			return
+		}
+	}
+	if s.datagram() || s.backlogLen+len(p) > maxSocketBacklog {
+		return
+	}
+	s.backlog = append(s.backlog, append([]byte(nil), p...))
+	s.backlogLen += len(p)
+}
+
+// send writes p to the connection, dropping the connection if the write fails.
+func (s *socketOutput) send(now time.Time, p []byte) bool {
+	_ = s.conn.SetWriteDeadline(now.Add(socketWriteTimeout))
+	if _, err := s.conn.Write(p); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return false
+	}
+	return true
+}
+
+// close closes the connection, discarding held entries. A connection established by a
+// background dial still in progress is closed when it completes.
+func (s *socketOutput) close() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	if s.dialing {
+		s.dialing = false
+		go func() {
+			if conn := <-s.dialed; conn != nil {
+				conn.Close()
+			}
+		}()
+	}
+}
@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSocketOutputsAudit(t *testing.T) {
+	tests := []struct {
+		name    string
+		network string
+		addr    func(t *testing.T) string
+		set     func(l *Logger, network, addr string) error
+	}{
+		{"unix socket", "unix",
+			func(t *testing.T) string { return filepath.Join(t.TempDir(), "log.sock") },
+			func(l *Logger, _, addr string) error { return l.SetUnixSocketOutput(addr) }},
+		{"network output", "tcp",
+			func(t *testing.T) string { return "127.0.0.1:0" },
+			func(l *Logger, network, addr string) error { return l.SetNetworkOutput(network, addr) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, err := net.Listen(tt.network, tt.addr(t))
+			if err != nil {
+				t.Skip(err)
+			}
+			defer ln.Close()
+			received := make(chan string, 1)
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					received <- ""
+					return
+				}
+				defer conn.Close()
+				data, _ := io.ReadAll(conn)
+				received <- string(data)
+			}()
+
+			l := NewLogger(DISABLED, ERROR)
+			l.SetConsoleWriter(io.Discard)
+			if err := l.SetLogFile(filepath.Join(t.TempDir(), "app.log")); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.set(l, tt.network, ln.Addr().String()); err != nil {
+				t.Fatal(err)
+			}
+			l.Info("below file level")
+			l.Audit("user login")
+			l.Close()
+
+			got := <-received
+			if !strings.Contains(got, "user login") {
+				t.Errorf("audit entry missing from %s: %q", tt.name, got)
+			}
+			if strings.Contains(got, "below file level") {
+				t.Errorf("filtered entry written to %s: %q", tt.name, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package logger
+
+import "time"
+
+// Span logs "▶ name" at INFO level and returns a function that logs "◀ name (duration)"
+// when the operation ends, e.g.
+//
+//	defer log.Span("load config")()
+//
+// Nested spans are indented by two spaces per level in text output; every span entry
+// carries a "depth" field (and the end entry a "duration" field) for structured output.
+// The depth is shared by all goroutines using the logger.
+func (l *Logger) Span(name string) func() {
+	start := time.Now()
+
+	l.mu.Lock()
+	depth := l.spanDepth
+	l.spanDepth++
+	e := l.newEntry(start, INFO, "▶ "+name, []Field{Int("depth", depth)})
+	e.indent = depth
+	l.writeEntry(&e)
+	l.mu.Unlock()
+
+	return func() {
+		t := time.Now()
+		d := t.Sub(start)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.spanDepth > 0 {
+			l.spanDepth--
+		}
+		e := l.newEntry(t, INFO, "◀ "+name+" ("+d.String()+")", []Field{Int("depth", depth), Dur("duration", d)})
+		e.indent = depth
+		l.writeEntry(&e)
+	}
+}
@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// defaultSQLQueryLimit is the default maximum length, in runes, of queries logged by SQL.
+const defaultSQLQueryLimit = 1000
+
+// SetSQLQueryLimit sets the maximum length, in runes, of queries logged by SQL
+// (1000 by default); longer queries are cut and marked "...(truncated)".
+// A limit < 0 logs queries in full.
+func (l *Logger) SetSQLQueryLimit(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sqlQueryLimit = n
+}
+
+// SetSQLRedactArgs configures whether SQL replaces every argument value with "[REDACTED]".
+// By default only named arguments whose name is a redacted key are hidden (see SetRedactKeys).
+func (l *Logger) SetSQLRedactArgs(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sqlRedactArgs = enabled
+}
+
+// SQL logs a database query with its duration and arguments, at DEBUG level or at
+// ERROR level with the error if err is not nil. Whitespace runs in the query are collapsed
+// to single spaces, except inside quoted literals and identifiers. Arguments are logged as "arg.1", "arg.2", ... fields, or "arg.<name>"
+// for sql.NamedArg values.
+func (l *Logger) SQL(query string, args []interface{}, d time.Duration, err error) {
+	level := DEBUG
+	if err != nil {
+		level = ERROR
+	}
+
+	l.mu.Lock()
+	limit := l.sqlQueryLimit
+	if limit == 0 {
+		limit = defaultSQLQueryLimit
+	}
+	fields := make([]Field, 0, len(args)+3)
+	fields = append(fields, Str("query", truncateQuery(normalizeQuery(query), limit)), Dur("duration", d))
+	for i, arg := range args {
+		key := "arg." + strconv.Itoa(i+1)
+		redact := l.sqlRedactArgs
+		if named, ok := arg.(sql.NamedArg); ok {
+			key = "arg." + named.Name
+			arg = named.Value
+			redact = redact || l.isRedacted(named.Name)
+		}
+		if redact {
+			fields = append(fields, Str(key, redactedValue))
+		} else {
+			fields = append(fields, Any(key, arg))
+		}
+	}
+	l.mu.Unlock()
+
+	if err != nil {
+		fields = append(fields, Err(err))
+	}
+	l.output(level, "sql", fields)
+}
+
+// normalizeQuery trims q and collapses its whitespace runs to single spaces, copying
+// quoted literals and identifiers ('...', "...", `...`) unchanged. A backslash inside
+// quotes escapes the next byte.
+func normalizeQuery(q string) string {
+	var b strings.Builder
+	b.Grow(len(q))
+	var quote byte // closing quote of the literal being copied, 0 outside literals
+	space := false
+	for i := 0; i < len(q); i++ {
+		c := q[i]
+		if quote != 0 {
+			b.WriteByte(c)
+			switch {
+			case c == '\\' && i+1 < len(q):
+				i++
+				b.WriteByte(q[i])
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case ' ', '\t', '\n', '\r', '\v', '\f':
+			space = true
+			continue
+		case '\'', '"', '`':
+			quote = c
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// truncateQuery cuts q to limit runes; a limit < 0 disables truncation.
+func truncateQuery(q string, limit int) string {
+	if limit < 0 || utf8.RuneCountInString(q) <= limit {
+		return q
+	}
+	n := 0
+	for i := range q {
+		if n == limit {
+			return q[:i] + "...(truncated)"
+		}
+		n++
+	}
+	return q
+}
@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"  SELECT *\n\tFROM t  ", "SELECT * FROM t"},
+		{"SELECT 'a  b'\n FROM t", "SELECT 'a  b' FROM t"},
+		{`SELECT "my  col" FROM t`, `SELECT "my  col" FROM t`},
+		{"SELECT `my  col` FROM t", "SELECT `my  col` FROM t"},
+		{"WHERE name = 'it''s  here'  AND x", "WHERE name = 'it''s  here' AND x"},
+		{`WHERE name = 'it\'s  here'  AND x`, `WHERE name = 'it\'s  here' AND x`},
+		{"WHERE a = 'x\n  y'", "WHERE a = 'x\n  y'"},
+	}
+	for _, tt := range tests {
+		if got := normalizeQuery(tt.query); got != tt.want {
+			t.Errorf("normalizeQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSQLKeepsLiterals(t *testing.T) {
+	l, console := newTestLogger(t)
+	l.SQL("SELECT id\n  FROM users WHERE note = 'two  spaces'", nil, time.Millisecond, nil)
+	if out := console.String(); !strings.Contains(out, `query="SELECT id FROM users WHERE note = 'two  spaces'"`) {
+		t.Errorf("query not logged as run: %q", out)
+	}
+}
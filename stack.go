@@ -0,0 +1,10 @@
+package logger
+
+// SetStackTraceLevel attaches a stack trace of the logging goroutine to every entry
+// at or above minLevel (by severity, see SetSeverity). Stacks are captured only for
+// entries meeting the threshold. DISABLED (the default) turns stack traces off.
+func (l *Logger) SetStackTraceLevel(minLevel LogLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stackLevel = minLevel
+}
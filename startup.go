@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SetVersion sets the application version reported by LogStartupContext.
+func (l *Logger) SetVersion(version string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.version = version
+}
+
+// SetStartupEnvPrefixes sets which environment variables LogStartupContext includes,
+// by name prefix (e.g. "APP_", "DB_"). No variables are included by default.
+func (l *Logger) SetStartupEnvPrefixes(prefixes ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.envPrefixes = append([]string(nil), prefixes...)
+}
+
+// LogStartupContext logs the program name, version, pid, command-line arguments and the
+// environment variables matching SetStartupEnvPrefixes at INFO level. Variables whose
+// name contains a redacted key (see SetRedactKeys), e.g. DB_PASSWORD, are redacted.
+func (l *Logger) LogStartupContext() {
+	l.mu.Lock()
+	fields := []Field{
+		Str("program", filepath.Base(os.Args[0])),
+	}
+	if l.version != "" {
+		fields = append(fields, Str("version", l.version))
+	}
+	fields = append(fields,
+		Int("pid", os.Getpid()),
+		Str("args", strings.Join(os.Args[1:], " ")),
+	)
+
+	var env []string
+	for _, kv := range os.Environ() {
+		for _, prefix := range l.envPrefixes {
+			if strings.HasPrefix(kv, prefix) {
+				env = append(env, kv)
+				break
+			}
+		}
+	}
+	sort.Strings(env)
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		if l.isSecretEnv(name) {
+			value = redactedValue
+		}
+		fields = append(fields, Str("env."+name, value))
+	}
+	l.mu.Unlock()
+
+	l.output(INFO, "startup", fields)
+}
+
+// isSecretEnv reports whether the environment variable name contains a redacted key.
+// Must be called with l.mu held.
+func (l *Logger) isSecretEnv(name string) bool {
+	name = strings.ToLower(name)
+	keys := defaultRedactKeys
+	if l.redactKeys != nil {
+		keys = make([]string, 0, len(l.redactKeys))
+		for k := range l.redactKeys {
+			keys = append(keys, k)
+		}
+	}
+	for _, k := range keys {
+		if strings.Contains(name, k) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"strconv"
+	"time"
+)
+
+// defaultStartupBufferSize is the number of entries held by SetStartupBuffer by default.
+const defaultStartupBufferSize = 100
+
+// startupBuffer holds entries logged before Ready (or while paused, see SetPauseBuffer).
+type startupBuffer struct {
+	entries []entry
+	size    int
+	dropped int
+}
+
+// SetStartupBuffer holds entries in memory instead of writing them until Ready is called,
+// so messages logged during initialization (e.g. by libraries, before main configures the
+// log file and levels) are written with the final configuration, including the field
+// allowlist, redaction and error classifiers; the caller and stack trace are captured when
+// the entry is logged. At most size entries are kept (100 if size <= 0); later ones are
+// dropped and counted. Audit entries are never held. Close calls Ready if it has not been
+// called.
+func (l *Logger) SetStartupBuffer(size int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if size <= 0 {
+		size = defaultStartupBufferSize
+	}
+	if l.startup != nil {
+		l.startup.size = size
+		return
+	}
+	l.startup = &startupBuffer{size: size}
+}
+
+// Ready ends startup buffering and writes the held entries, in order, to the outputs
+// configured now. If entries were dropped, a FAIL entry reports how many.
+func (l *Logger) Ready() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.releaseStartup()
+}
+
+// releaseStartup writes and discards the startup buffer, if any.
+// Must be called with l.mu held.
+func (l *Logger) releaseStartup() {
+	sb := l.startup
+	if sb == nil {
+		return
+	}
+	l.startup = nil
+	for i := range sb.entries {
+		l.writeEntry(&sb.entries[i])
+	}
+	if sb.dropped > 0 {
+		l.emit(time.Now(), FAIL, "startup buffer full, dropped "+strconv.Itoa(sb.dropped)+" entries", nil)
+	}
+}
+
+// hold adds e to the startup buffer.
+func (sb *startupBuffer) hold(e *entry) {
+	if len(sb.entries) >= sb.size {
+		sb.dropped++
+		return
+	}
+	sb.entries = append(sb.entries, *e)
+}
@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHeldEntriesFilteredOnRelease(t *testing.T) {
+	tests := []struct {
+		name    string
+		hold    func(l *Logger)
+		release func(l *Logger)
+	}{
+		{"startup buffer", func(l *Logger) { l.SetStartupBuffer(0) }, (*Logger).Ready},
+		{"pause buffer", func(l *Logger) { l.SetPauseBuffer(10); l.Pause() }, (*Logger).Resume},
+		{"goroutine group", func(l *Logger) { l.SetGoroutineGrouping(time.Hour) }, func(l *Logger) { _ = l.Flush() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console := newTestLogger(t)
+			tt.hold(l)
+			l.Event(INFO, "early", Str("ssn", "123"), Str("user", "alice"), Str("debug", "x"))
+			l.SetRedactKeys("ssn")
+			l.SetFieldAllowlist([]string{"ssn", "user"})
+			tt.release(l)
+
+			got := textMessages(console.String())
+			want := []string{"early dropped_fields=1 ssn=[REDACTED] user=alice"}
+			if len(got) != 1 || got[0] != want[0] {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
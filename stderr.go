@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// maxOrderWait bounds how long a stderr write waits for queued stdout output.
+const maxOrderWait = 100 * time.Millisecond
+
+// SetStderrLevel routes console entries at level and above to os.Stderr instead of the
+// console writer, e.g. SetStderrLevel(ERROR) keeps errors visible when stdout is piped.
+// DISABLED (the default) writes everything to the console writer. WithCapturedOutput
+// captures these entries with the console output.
+func (l *Logger) SetStderrLevel(level LogLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stderrLevel = level
+}
+
+// SetOrderedStreams keeps stdout and stderr entries in order when both streams are the
+// same terminal. Blocking writes are always ordered; with SetConsoleNonBlocking, a stderr
+// write first waits (up to 100ms, best effort) for the queued console output to be written.
+func (l *Logger) SetOrderedStreams(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.orderedStreams = enabled
+}
+
+// consoleWriterFor returns the console destination for an entry at level.
+// Must be called with l.mu held.
+func (l *Logger) consoleWriterFor(level LogLevel) io.Writer {
+	if !l.shouldLog(level, l.stderrLevel) {
+		return l.console
+	}
+	if l.orderedStreams && l.consoleAsync != nil && sameTerminal(l.consoleAsync.w, l.stderr) {
+		l.consoleAsync.waitIdle(maxOrderWait)
+	}
+	return l.stderr
+}
+
+// sameTerminal reports whether a and b are files referring to the same terminal.
+func sameTerminal(a, b io.Writer) bool {
+	fa, ok := a.(*os.File)
+	if !ok {
+		return false
+	}
+	fb, ok := b.(*os.File)
+	if !ok {
+		return false
+	}
+	ia, err := fa.Stat()
+	if err != nil || ia.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	ib, err := fb.Stat()
+	if err != nil {
+		return false
+	}
+	return os.SameFile(ia, ib)
+}
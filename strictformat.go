@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SetStrictFormat reports formatting mistakes in log calls, such as a missing or extra
+// argument or a wrong verb: when fmt flags the format string or its arguments (its "%!"
+// error markers), an entry with the offending format string and output is logged before
+// the message itself. Markers that are part of an argument's value, e.g. a URL holding
+// "%!", are not reported. The report is written at FAIL, the closest level to a warning
+// this package has, so it is not filtered out along with DEBUG and INFO output.
+func (l *Logger) SetStrictFormat(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.strictFormat = enabled
+}
+
+// checkFormat logs a FAIL entry if msg, formatted from format and args, carries a fmt
+// error marker not coming from the values of args and strict format checking is enabled.
+func (l *Logger) checkFormat(format, msg string, args []interface{}) {
+	if !strings.Contains(msg, "%!") {
+		return
+	}
+	l.mu.Lock()
+	strict := l.strictFormat
+	l.mu.Unlock()
+	if !strict {
+		return
+	}
+	masked := make([]interface{}, len(args))
+	for i, arg := range args {
+		masked[i] = maskArg(arg)
+	}
+	if strings.Contains(fmt.Sprintf(format, masked...), "%!") {
+		l.output(FAIL, "malformed log format string", []Field{Str("format", format), Str("output", msg)})
+	}
+}
+
+// maskArg wraps arg so that formatting it writes no "%!" of its own value. Numbers and
+// booleans, which cannot contain one and may be consumed by '*' widths, are kept as is.
+func maskArg(arg interface{}) interface{} {
+	switch arg.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr,
+		float32, float64, complex64, complex128:
+		return arg
+	}
+	return maskedArg{arg}
+}
+
+// maskedArg formats its value like fmt does, with "%!" sequences in the result defused
+// except for the "%!verb(" markers fmt writes when the verb does not fit the value or,
+// in structs and slices, one of its elements.
+type maskedArg struct{ v interface{} }
+
+// Format implements fmt.Formatter.
+func (a maskedArg) Format(f fmt.State, verb rune) {
+	directive := []byte{'%'}
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			directive = append(directive, byte(flag))
+		}
+	}
+	if w, ok := f.Width(); ok {
+		directive = strconv.AppendInt(directive, int64(w), 10)
+	}
+	if p, ok := f.Precision(); ok {
+		directive = append(directive, '.')
+		directive = strconv.AppendInt(directive, int64(p), 10)
+	}
+	directive = append(directive, string(verb)...)
+
+	s := fmt.Sprintf(string(directive), a.v)
+	bad := "%!" + string(verb) + "("
+	var b strings.Builder
+	for {
+		i := strings.Index(s, "%!")
+		if i < 0 {
+			break
+		}
+		b.WriteString(s[:i])
+		if strings.HasPrefix(s[i:], bad) {
+			b.WriteString("%!")
+		} else {
+			b.WriteString("%_")
+		}
+		s = s[i+2:]
+	}
+	b.WriteString(s)
+	_, _ = io.WriteString(f, b.String())
+}
@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stringer is a fmt.Stringer returning its own value.
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+func TestStrictFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		args   []interface{}
+		report bool
+	}{
+		{"correct", "user %s logged in", []interface{}{"alice"}, false},
+		{"missing argument", "user %s logged in", nil, true},
+		{"extra argument", "user logged in", []interface{}{"alice"}, true},
+		{"wrong verb", "count %d", []interface{}{"many"}, true},
+		{"wrong verb on error", "count %d", []interface{}{errors.New("x")}, true},
+		{"marker in string value", "fetching %s", []interface{}{"https://x.test/?q=%!s"}, false},
+		{"marker in error value", "failed: %v", []interface{}{errors.New("bad input %!d(")}, false},
+		{"marker in stringer value", "got %q", []interface{}{stringer("%!x")}, false},
+		{"marker in struct value", "got %+v", []interface{}{struct{ S string }{"%!"}}, false},
+		{"star width", "%*d|%-8s|", []interface{}{4, 7, "%!"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, console := newTestLogger(t)
+			l.SetStrictFormat(true)
+			l.Info(tt.format, tt.args...)
+			if got := strings.Contains(console.String(), "malformed log format string"); got != tt.report {
+				t.Errorf("reported = %v, want %v: %q", got, tt.report, console.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SetSummaryOnClose enables a final INFO entry written by Close with the number of
+// entries logged per level, e.g. "logged: 0 debug, 1200 info, 4 success, 0 fail, 3 error".
+// Only entries written to at least one output are counted; entries below every output's
+// level (or dropped by sampling) are not.
+func (l *Logger) SetSummaryOnClose(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.summaryOnClose = enabled
+}
+
+// countLevel records an entry written to at least one output for the close summary.
+func (l *Logger) countLevel(level LogLevel) {
+	if level >= DEBUG && level < DISABLED {
+		l.levelCounts[level].Add(1)
+	}
+}
+
+// writeSummary emits the per-level counts.
+// Must be called with l.mu held.
+func (l *Logger) writeSummary() {
+	parts := make([]string, 0, DISABLED)
+	for level := DEBUG; level < DISABLED; level++ {
+		parts = append(parts, fmt.Sprintf("%d %s", l.levelCounts[level].Load(), strings.ToLower(levelToString(level))))
+	}
+	l.emit(time.Now(), INFO, "logged: "+strings.Join(parts, ", "), nil)
+}
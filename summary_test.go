@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSummaryCountsWrittenEntries(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(l *Logger)
+		want  string
+	}{
+		{"filtered by level", func(l *Logger) {}, "logged: 0 debug, 1 info, 0 success, 1 fail, 0 error"},
+		{"debug sink", func(l *Logger) { l.AddSink(&recordSink{}, DEBUG, FormatText) }, "logged: 2 debug, 1 info, 0 success, 1 fail, 0 error"},
+		{"console off", func(l *Logger) { l.SetConsoleLevel(DISABLED) }, "logged: 0 debug, 0 info, 0 success, 0 fail, 0 error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var console bytes.Buffer
+			l := NewLogger(INFO, DISABLED)
+			l.SetConsoleWriter(&console)
+			l.SetColorEnabled(false)
+			l.SetSummaryOnClose(true)
+			tt.setup(l)
+			l.Debug("one")
+			l.Debug("two")
+			l.Info("three")
+			l.Fail("four")
+			l.SetConsoleLevel(INFO)
+			l.Close()
+			if !strings.Contains(console.String(), tt.want) {
+				t.Errorf("output = %q, want summary %q", console.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// defaultTableRowLimit is the default maximum number of rows rendered by Table.
+const defaultTableRowLimit = 50
+
+// tableData is a table attached to an entry by Table.
+type tableData struct {
+	columns []string
+	rows    []map[string]interface{}
+	omitted int // rows beyond the limit
+}
+
+// SetTableRowLimit sets the maximum number of rows Table renders (50 by default).
+// A limit <= 0 renders all rows.
+func (l *Logger) SetTableRowLimit(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tableRowLimit = n
+}
+
+// Table logs rows as an aligned ASCII table in text output and as a "rows" array in JSON.
+// Columns are the union of all row keys, sorted by name.
+func (l *Logger) Table(level LogLevel, rows []map[string]interface{}) {
+	t := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit := l.tableRowLimit
+	if limit == 0 {
+		limit = defaultTableRowLimit
+	}
+	td := &tableData{rows: rows}
+	if limit > 0 && len(rows) > limit {
+		td.rows = rows[:limit]
+		td.omitted = len(rows) - limit
+	}
+
+	seen := make(map[string]bool)
+	for _, row := range td.rows {
+		for k := range row {
+			if !seen[k] {
+				seen[k] = true
+				td.columns = append(td.columns, k)
+			}
+		}
+	}
+	sort.Strings(td.columns)
+
+	e := l.newEntry(t, level, "table ("+strconv.Itoa(len(rows))+" rows)", nil)
+	e.table = td
+	l.writeEntry(&e)
+}
+
+// appendText appends the table as lines, each preceded by a newline. With sanitize,
+// control characters in column names and cells are escaped.
+func (td *tableData) appendText(buf []byte, sanitize bool) []byte {
+	columns := td.columns
+	if sanitize {
+		columns = make([]string, len(td.columns))
+		for i, c := range td.columns {
+			columns[i] = string(appendSanitized(nil, c, false))
+		}
+	}
+	cells := make([][]string, len(td.rows))
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		widths[i] = utf8.RuneCountInString(c)
+	}
+	for r, row := range td.rows {
+		cells[r] = make([]string, len(td.columns))
+		for i, c := range td.columns {
+			v, ok := row[c]
+			s := ""
+			if ok {
+				s = fmt.Sprint(v)
+				if sanitize {
+					s = string(appendSanitized(nil, s, false))
+				} else {
+					s = strings.ReplaceAll(s, "\n", " ")
+				}
+			}
+			cells[r][i] = s
+			if w := utf8.RuneCountInString(s); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	border := func(buf []byte) []byte {
+		buf = append(buf, "\n+"...)
+		for _, w := range widths {
+			buf = append(buf, strings.Repeat("-", w+2)...)
+			buf = append(buf, '+')
+		}
+		return buf
+	}
+	line := func(buf []byte, values []string) []byte {
+		buf = append(buf, "\n|"...)
+		for i, v := range values {
+			buf = append(buf, ' ')
+			buf = append(buf, v...)
+			buf = append(buf, strings.Repeat(" ", widths[i]-utf8.RuneCountInString(v)+1)...)
+			buf = append(buf, '|')
+		}
+		return buf
+	}
+
+	buf = border(buf)
+	buf = line(buf, columns)
+	buf = border(buf)
+	for _, row := range cells {
+		buf = line(buf, row)
+	}
+	buf = border(buf)
+	if td.omitted > 0 {
+		buf = append(buf, "\n... "...)
+		buf = strconv.AppendInt(buf, int64(td.omitted), 10)
+		buf = append(buf, " more rows"...)
+	}
+	return buf
+}
+
+// appendJSON appends the table rows as a JSON array.
+func (td *tableData) appendJSON(buf []byte) []byte {
+	data, err := json.Marshal(td.rows)
+	if err != nil {
+		return appendJSONString(buf, err.Error())
+	}
+	return append(buf, data...)
+}
@@ -0,0 +1,104 @@
+package logger
+
+import "strconv"
+
+// Theme is a preset of console colors per level.
+type Theme int
+
+// Available color themes.
+const (
+	ThemeDark  Theme = iota // default palette for dark terminals
+	ThemeLight              // avoids yellow, which is hard to read on light backgrounds
+	ThemeNone               // no colors
+)
+
+// themeColors holds the per-level colors of each theme.
+var themeColors = map[Theme]map[LogLevel]string{
+	ThemeDark: {
+		DEBUG:   dimGray,
+		INFO:    blue,
+		SUCCESS: green,
+		FAIL:    boldRed,
+		ERROR:   red,
+	},
+	ThemeLight: {
+		DEBUG:   purple,
+		INFO:    blue,
+		SUCCESS: green,
+		FAIL:    boldRed,
+		ERROR:   red,
+	},
+	ThemeNone: {
+		DEBUG:   "",
+		INFO:    "",
+		SUCCESS: "",
+		FAIL:    "",
+		ERROR:   "",
+	},
+}
+
+// SetTheme replaces all level colors with a preset palette.
+func (l *Logger) SetTheme(t Theme) {
+	colors, ok := themeColors[t]
+	if !ok {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levelColors = make(map[LogLevel]string, len(colors))
+	for level, code := range colors {
+		l.levelColors[level] = code
+	}
+}
+
+// SetLevelColor sets the ANSI escape sequence used for level on the console, e.g.
+// "\033[36m" or SGR(SGRBold, SGRCyan) for composite attributes.
+// An empty code disables coloring for that level.
+func (l *Logger) SetLevelColor(level LogLevel, code string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.levelColors == nil {
+		l.levelColors = make(map[LogLevel]string)
+	}
+	l.levelColors[level] = code
+}
+
+// levelColor returns the ANSI color used for level on the console.
+// Must be called with l.mu held.
+func (l *Logger) levelColor(level LogLevel) string {
+	if code, ok := l.levelColors[level]; ok {
+		return code
+	}
+	if code, ok := themeColors[ThemeDark][level]; ok {
+		return code
+	}
+	return yellow
+}
+
+// SGR parameters for building console styles with SGR.
+const (
+	SGRBold    = 1
+	SGRDim     = 2
+	SGRRed     = 31
+	SGRGreen   = 32
+	SGRYellow  = 33
+	SGRBlue    = 34
+	SGRMagenta = 35
+	SGRCyan    = 36
+	SGRWhite   = 37
+	SGRGray    = 90
+)
+
+// SGR returns the ANSI escape sequence combining the given SGR parameters,
+// e.g. SGR(SGRBold, SGRRed) returns "\033[1;31m".
+func SGR(params ...int) string {
+	buf := make([]byte, 0, 2+4*len(params))
+	buf = append(buf, "\033["...)
+	for i, p := range params {
+		if i > 0 {
+			buf = append(buf, ';')
+		}
+		buf = strconv.AppendInt(buf, int64(p), 10)
+	}
+	return string(append(buf, 'm'))
+}
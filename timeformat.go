@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"strconv"
+	"time"
+)
+
+// TimePrecision controls the fractional-second precision of timestamps.
+type TimePrecision int
+
+// Available timestamp precisions.
+const (
+	Micros  TimePrecision = iota // 02/01/2006 15:04:05.000000 (default)
+	Seconds                      // 02/01/2006 15:04:05
+	Millis                       // 02/01/2006 15:04:05.000
+	Nanos                        // 02/01/2006 15:04:05.000000000
+)
+
+// timeLayout returns the time.Format layout for the precision.
+func (p TimePrecision) timeLayout() string {
+	switch p {
+	case Seconds:
+		return "02/01/2006 15:04:05"
+	case Millis:
+		return "02/01/2006 15:04:05.000"
+	case Nanos:
+		return "02/01/2006 15:04:05.000000000"
+	default:
+		return "02/01/2006 15:04:05.000000"
+	}
+}
+
+// SetTimePrecision sets the precision of entry timestamps (microseconds by default).
+func (l *Logger) SetTimePrecision(p TimePrecision) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.timePrecision = p
+}
+
+// SetRelativeTime replaces the wall-clock timestamp in text output with the time elapsed
+// since the logger was created (e.g. "+1.234s"). JSON output keeps "time" and adds an
+// "elapsed" field in seconds.
+func (l *Logger) SetRelativeTime(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.relativeTime = enabled
+}
+
+// appendElapsed appends d as "+S.mmms".
+func appendElapsed(buf []byte, d time.Duration) []byte {
+	buf = append(buf, '+')
+	buf = strconv.AppendFloat(buf, d.Seconds(), 'f', 3, 64)
+	return append(buf, 's')
+}
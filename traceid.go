@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// traceIDKey is the context key under which a trace ID is stored.
+type traceIDKey struct{}
+
+// traceIDField is the field key under which trace IDs are logged.
+const traceIDField = "trace_id"
+
+var (
+	goroutineTraceIDs sync.Map // goroutine ID -> trace ID
+	activeTraceIDs    atomic.Int64
+)
+
+// WithTraceID returns a copy of ctx carrying a trace ID, logged as a "trace_id" field by
+// the *Ctx methods (LogCtx, InfoCtx, ...). This is the preferred way to tag a request.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, id)
+}
+
+// TraceIDFromContext returns the trace ID stored in ctx by WithTraceID, if any.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(traceIDKey{}).(string)
+	return id, ok
+}
+
+// SetTraceID binds a trace ID to the calling goroutine: every entry logged from this
+// goroutine, by any logger, carries it as a "trace_id" field until ClearTraceID. It exists
+// for code that cannot pass a context around; prefer WithTraceID otherwise.
+//
+// Goroutines are identified by parsing the header of runtime.Stack, which costs about a
+// microsecond per log call while any goroutine has a trace ID set. The ID is not inherited
+// by goroutines started afterwards, and it must be cleared before the goroutine exits
+// (typically with defer ClearTraceID()) or it is kept in memory forever.
+func SetTraceID(id string) {
+	if _, loaded := goroutineTraceIDs.Swap(goroutineID(), id); !loaded {
+		activeTraceIDs.Add(1)
+	}
+}
+
+// ClearTraceID removes the trace ID bound to the calling goroutine by SetTraceID.
+func ClearTraceID() {
+	if _, loaded := goroutineTraceIDs.LoadAndDelete(goroutineID()); loaded {
+		activeTraceIDs.Add(-1)
+	}
+}
+
+// goroutineTraceID returns the trace ID bound to the calling goroutine, or "".
+func goroutineTraceID() string {
+	if activeTraceIDs.Load() == 0 {
+		return ""
+	}
+	if id, ok := goroutineTraceIDs.Load(goroutineID()); ok {
+		return id.(string)
+	}
+	return ""
+}
+
+// goroutineID returns the ID of the calling goroutine from the "goroutine N [" stack header.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+// appendTraceID appends the goroutine's trace ID to fields unless they already carry one.
+func appendTraceID(fields []Field) []Field {
+	id := goroutineTraceID()
+	if id == "" {
+		return fields
+	}
+	for _, f := range fields {
+		if f.Key == traceIDField {
+			return fields
+		}
+	}
+	return append(fields[:len(fields):len(fields)], Str(traceIDField, id))
+}
@@ -0,0 +1,22 @@
+package logger
+
+import "fmt"
+
+// WrapError logs "<message>: <err>" at ERROR level and returns err wrapped with the
+// same message, so a failure can be logged and propagated in one line:
+//
+//	return log.WrapError(err, "loading config %q", path)
+//
+// Fields from error classifiers (see RegisterErrorClassifier) are attached.
+// A nil err is returned as nil without logging.
+func (l *Logger) WrapError(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	msg := fmt.Sprintf(format, args...)
+	l.mu.Lock()
+	fields := l.classifyError(nil, err)
+	l.mu.Unlock()
+	l.output(ERROR, msg+": "+err.Error(), fields)
+	return fmt.Errorf("%s: %w", msg, err)
+}